@@ -27,11 +27,13 @@
 //          go run weather.go -w here -u f -l ru          # fahrenheit, Russian
 //          go run weather.go -w Dublin -u c -l fi        # celcius, Finnish
 //          go run weather.go -w "Las Vegas" -u k -l es   # kelvin, Spanish
+//          go run weather.go -w Cowes -u c -l en -p marine # one line marine summary
 package main
 
 import (
 	"encoding/json"
 	"flag"
+	"fmt"
 	owm "github.com/briandowns/openweathermap" // "owm" for easier use
 	"io/ioutil"
 	"log"
@@ -48,6 +50,7 @@ const URL = "http://ip-api.com/json"
 const weatherTemplate = `Current weather for {{.Name}}:
     Conditions: {{range .Weather}} {{.Description}} {{end}}
     Now:         {{.Main.Temp}} {{.Unit}}
+    Feels like:  {{.Main.FeelsLike}} {{.Unit}}
     High:        {{.Main.TempMax}} {{.Unit}}
     Low:         {{.Main.TempMin}} {{.Unit}}
 `
@@ -56,6 +59,7 @@ const forecastTemplate = `Weather Forecast for {{.City.Name}}:
 {{range .List}}Date & Time: {{.DtTxt}}
 Conditions:  {{range .Weather}}{{.Main}} {{.Description}}{{end}}
 Temp:        {{.Main.Temp}} 
+Feels like:  {{.Main.FeelsLike}}
 High:        {{.Main.TempMax}} 
 Low:         {{.Main.TempMin}}
 
@@ -68,6 +72,7 @@ var (
 	unitFlag  = flag.String("u", "", "Unit of measure to display temps in")
 	langFlag  = flag.String("l", "", "Language to display temps in")
 	whenFlag  = flag.String("t", "current", "current | forecast")
+	profFlag  = flag.String("p", "", "Print a one line summary of the current weather: standard | marine | aviation")
 )
 
 // Data will hold the result of the query to get the IP
@@ -169,6 +174,14 @@ func main() {
 		if err != nil {
 			log.Fatalln(err)
 		}
+		if *profFlag != "" {
+			summary, err := owm.Summarize(w, owm.Profile(*profFlag), nil)
+			if err != nil {
+				log.Fatalln(err)
+			}
+			fmt.Println(summary)
+			os.Exit(0)
+		}
 		tmpl, err := template.New("weather").Parse(weatherTemplate)
 		if err != nil {
 			log.Fatalln(err)
@@ -82,14 +82,13 @@ func hereHandler(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 	// Process our template
-	t, err := template.ParseFiles("templates/here.html")
+	t, err := template.New("here.html").Funcs(template.FuncMap{
+		"attribution": func() template.HTML { return template.HTML(owm.AttributionHTML()) },
+	}).ParseFiles("templates/here.html")
 	if err != nil {
 		fmt.Fprint(w, http.StatusInternalServerError)
 		return
 	}
-	// We're doin' naughty things below... Ignoring icon file size and possible errors.
-	_, _ = owm.RetrieveIcon("static/img", wd.Weather[0].Icon+".png")
-
 	// Write out the template with the given data
 	t.Execute(w, wd)
 }
@@ -97,9 +96,7 @@ func hereHandler(w http.ResponseWriter, r *http.Request) {
 // Run the app
 func main() {
 	http.HandleFunc("/here", hereHandler)
-	// Make sure we can serve our icon files once retrieved
-	http.HandleFunc("/static/", func(w http.ResponseWriter, r *http.Request) {
-		http.ServeFile(w, r, r.URL.Path[1:])
-	})
+	// Serve the icon files, retrieving and caching them locally on first use
+	http.Handle("/static/img/", owm.IconHandler("static/img"))
 	http.ListenAndServe(":8888", nil)
 }
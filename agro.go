@@ -0,0 +1,335 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"image"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var errPolygonID = errors.New("polygon ID required")
+var errInvalidPolygon = errors.New("polygon needs a name and at least 3 corners")
+
+// Agro gives access to the Agro API, which serves satellite and soil
+// data for polygons, areas of farmland registered with the API.  The
+// Agro API is a separate product of OWM, reached on its own host; the
+// key must be enabled for it.
+type Agro struct {
+	c *Client
+}
+
+// Agro returns the Agro service of the Client.
+func (c *Client) Agro() *Agro {
+	return &Agro{c: c}
+}
+
+// url returns the Agro API URL of path with params and the API key.
+func (a *Agro) url(path string, params url.Values) string {
+	q := url.Values{}
+	for k, vs := range params {
+		q[k] = vs
+	}
+	q.Set("appid", a.c.key)
+	return fmt.Sprintf(agroURL, path, q.Encode())
+}
+
+// Soil holds the soil data of a polygon.  Temperatures are in kelvin
+// whatever the units of the Client; KelvinToCelsius converts them.
+type Soil struct {
+	Dt Timestamp `json:"dt"`
+	// T0 is the temperature at the surface.
+	T0 float64 `json:"t0"`
+	// T10 is the temperature 10 centimeters deep.
+	T10 float64 `json:"t10"`
+	// Moisture is the volumetric water content of the soil, in m³/m³.
+	Moisture float64 `json:"moisture"`
+}
+
+// Soil returns the current soil data of the polygon with the given ID.
+// The soil endpoint only serves registered polygons, not arbitrary
+// coordinates.
+func (a *Agro) Soil(ctx context.Context, polygonID string) (*Soil, error) {
+	if polygonID == "" {
+		return nil, errPolygonID
+	}
+	s := &Soil{}
+	if err := a.c.get(ctx, a.url("soil", url.Values{"polyid": {polygonID}}), s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// SoilHistory returns the soil data of the polygon with the given ID
+// from start to end.  Historical soil data needs a paid Agro plan.
+func (a *Agro) SoilHistory(ctx context.Context, polygonID string, start, end time.Time) ([]Soil, error) {
+	if polygonID == "" {
+		return nil, errPolygonID
+	}
+	if !start.Before(end) {
+		return nil, errInvalidTimeRange
+	}
+	params := url.Values{
+		"polyid": {polygonID},
+		"start":  {strconv.FormatInt(start.Unix(), 10)},
+		"end":    {strconv.FormatInt(end.Unix(), 10)},
+	}
+
+	var s []Soil
+	if err := a.c.get(ctx, a.url("soil/history", params), &s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Polygon is an area of farmland registered with the Agro API.
+type Polygon struct {
+	ID   string
+	Name string
+	// Geometry is the GeoJSON polygon of the area.
+	Geometry TriggerArea
+	Center   Coordinates
+	// Area is the size of the polygon in hectares.
+	Area      float64
+	UserID    string
+	CreatedAt time.Time
+}
+
+// UnmarshalJSON decodes a polygon as the Agro API returns it.
+func (p *Polygon) UnmarshalJSON(b []byte) error {
+	var raw struct {
+		ID      string `json:"id"`
+		Name    string `json:"name"`
+		GeoJSON struct {
+			Geometry TriggerArea `json:"geometry"`
+		} `json:"geo_json"`
+		Center    []float64 `json:"center"`
+		Area      float64   `json:"area"`
+		UserID    string    `json:"user_id"`
+		CreatedAt int64     `json:"created_at"`
+	}
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*p = Polygon{
+		ID:        raw.ID,
+		Name:      raw.Name,
+		Geometry:  raw.GeoJSON.Geometry,
+		Area:      raw.Area,
+		UserID:    raw.UserID,
+		CreatedAt: time.Unix(raw.CreatedAt, 0).UTC(),
+	}
+	if len(raw.Center) == 2 {
+		p.Center = Coordinates{Longitude: raw.Center[0], Latitude: raw.Center[1]}
+	}
+	return nil
+}
+
+// CreatePolygon registers the polygon with the given name and corners
+// and returns it with its ID.  The polygon is closed by repeating the
+// first corner.
+func (a *Agro) CreatePolygon(ctx context.Context, name string, corners ...Coordinates) (*Polygon, error) {
+	if name == "" || len(corners) < 3 {
+		return nil, errInvalidPolygon
+	}
+	type feature struct {
+		Type       string            `json:"type"`
+		Properties map[string]string `json:"properties"`
+		Geometry   TriggerArea       `json:"geometry"`
+	}
+	body := struct {
+		Name    string  `json:"name"`
+		GeoJSON feature `json:"geo_json"`
+	}{name, feature{"Feature", map[string]string{}, PolygonArea(corners...)}}
+
+	p := &Polygon{}
+	if err := a.c.sendJSON(ctx, http.MethodPost, a.url("polygons", nil), body, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Polygons returns the polygons of the API key.
+func (a *Agro) Polygons(ctx context.Context) ([]Polygon, error) {
+	var list []Polygon
+	if err := a.c.sendJSON(ctx, http.MethodGet, a.url("polygons", nil), nil, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Polygon returns the polygon with the given ID.
+func (a *Agro) Polygon(ctx context.Context, id string) (*Polygon, error) {
+	if id == "" {
+		return nil, errPolygonID
+	}
+	p := &Polygon{}
+	if err := a.c.sendJSON(ctx, http.MethodGet, a.url("polygons/"+url.PathEscape(id), nil), nil, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// RenamePolygon changes the name of the polygon with the given ID, the
+// only thing the Agro API lets change, and returns the updated polygon.
+func (a *Agro) RenamePolygon(ctx context.Context, id, name string) (*Polygon, error) {
+	if id == "" {
+		return nil, errPolygonID
+	}
+	if name == "" {
+		return nil, errInvalidPolygon
+	}
+	body := struct {
+		Name string `json:"name"`
+	}{name}
+
+	p := &Polygon{}
+	if err := a.c.sendJSON(ctx, http.MethodPut, a.url("polygons/"+url.PathEscape(id), nil), body, p); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DeletePolygon deletes the polygon with the given ID.
+func (a *Agro) DeletePolygon(ctx context.Context, id string) error {
+	if id == "" {
+		return errPolygonID
+	}
+	return a.c.sendJSON(ctx, http.MethodDelete, a.url("polygons/"+url.PathEscape(id), nil), nil, nil)
+}
+
+// Satellites whose images SearchImages finds.
+const (
+	SatelliteLandsat8  = "l8"
+	SatelliteSentinel2 = "s2"
+)
+
+// ImageQuery selects the satellite images of a polygon.  Only Start and
+// End are required.
+type ImageQuery struct {
+	Start time.Time
+	End   time.Time
+	// MaxClouds is the highest cloud coverage of the images, in %.
+	MaxClouds *float64
+	// MinCoverage is the lowest share of the polygon the images cover,
+	// in %.
+	MinCoverage *float64
+	// Satellite is SatelliteLandsat8 or SatelliteSentinel2; empty for
+	// both.
+	Satellite string
+}
+
+// SatelliteLayers holds the URLs of the layers of a satellite image:
+// true and false color images and vegetation and water indices.  The
+// URLs hold the API key.
+type SatelliteLayers struct {
+	TrueColor  string `json:"truecolor,omitempty"`
+	FalseColor string `json:"falsecolor,omitempty"`
+	NDVI       string `json:"ndvi"`
+	EVI        string `json:"evi"`
+	EVI2       string `json:"evi2"`
+	NRI        string `json:"nri"`
+	DSWI       string `json:"dswi"`
+	NDWI       string `json:"ndwi"`
+}
+
+// SatelliteImage is a satellite image of a polygon.
+type SatelliteImage struct {
+	Dt        Timestamp `json:"dt"`
+	Satellite string    `json:"type"`
+	// Coverage is the share of the polygon the image covers, in %.
+	Coverage float64 `json:"dc"`
+	// Clouds is the cloud coverage of the image, in %.
+	Clouds float64 `json:"cl"`
+	Sun    struct {
+		Elevation float64 `json:"elevation"`
+		Azimuth   float64 `json:"azimuth"`
+	} `json:"sun"`
+	// Image holds PNG images of the polygon, Tile map tile URL
+	// templates with {z}, {x} and {y} placeholders and Data GeoTIFF
+	// files.
+	Image SatelliteLayers `json:"image"`
+	Tile  SatelliteLayers `json:"tile"`
+	Data  SatelliteLayers `json:"data"`
+	// Stats holds the URLs of the IndexStats of the index layers.
+	Stats SatelliteLayers `json:"stats"`
+}
+
+// SearchImages returns the satellite images of the polygon with the
+// given ID matching q.
+func (a *Agro) SearchImages(ctx context.Context, polygonID string, q ImageQuery) ([]SatelliteImage, error) {
+	if polygonID == "" {
+		return nil, errPolygonID
+	}
+	if !q.Start.Before(q.End) {
+		return nil, errInvalidTimeRange
+	}
+	params := url.Values{
+		"polyid": {polygonID},
+		"start":  {strconv.FormatInt(q.Start.Unix(), 10)},
+		"end":    {strconv.FormatInt(q.End.Unix(), 10)},
+	}
+	if q.MaxClouds != nil {
+		params.Set("clouds_max", strconv.FormatFloat(*q.MaxClouds, 'f', -1, 64))
+	}
+	if q.MinCoverage != nil {
+		params.Set("coverage_min", strconv.FormatFloat(*q.MinCoverage, 'f', -1, 64))
+	}
+	if q.Satellite != "" {
+		params.Set("type", q.Satellite)
+	}
+
+	var images []SatelliteImage
+	if err := a.c.get(ctx, a.url("image/search", params), &images); err != nil {
+		return nil, err
+	}
+	return images, nil
+}
+
+// IndexStats holds the statistics of a vegetation or water index over
+// a polygon.  P25 and P75 are the first and third quartiles and Num the
+// number of pixels.
+type IndexStats struct {
+	Min    float64 `json:"min"`
+	Max    float64 `json:"max"`
+	Mean   float64 `json:"mean"`
+	Median float64 `json:"median"`
+	Std    float64 `json:"std"`
+	P25    float64 `json:"p25"`
+	P75    float64 `json:"p75"`
+	Num    int     `json:"num"`
+}
+
+// IndexStats returns the statistics at statsURL, one of the Stats URLs
+// of a SatelliteImage.
+func (a *Agro) IndexStats(ctx context.Context, statsURL string) (*IndexStats, error) {
+	s := &IndexStats{}
+	if err := a.c.get(ctx, statsURL, s); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// Image returns the PNG image at imageURL, one of the Image URLs of a
+// SatelliteImage.
+func (a *Agro) Image(ctx context.Context, imageURL string) (image.Image, error) {
+	return a.c.getPNG(ctx, imageURL)
+}
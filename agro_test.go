@@ -0,0 +1,209 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestAgroSoil(t *testing.T) {
+	t.Parallel()
+
+	a := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Host != "api.agromonitoring.com" || r.URL.Path != "/agro/1.0/soil" {
+			t.Errorf("unexpected request %s%s", r.Host, r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("polyid") != "5aaa8052cbbbb5000b73ff66" || q.Get("appid") != testKey {
+			t.Errorf("unexpected query %v", q)
+		}
+		fmt.Fprint(w, `{"dt":1522108800,"t10":281.96,"moisture":0.175,"t0":279.02}`)
+	}).Agro()
+
+	s, err := a.Soil(context.Background(), "5aaa8052cbbbb5000b73ff66")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Dt.Unix() != 1522108800 || !almostEqual(s.T0, 279.02) || !almostEqual(s.T10, 281.96) || !almostEqual(s.Moisture, 0.175) {
+		t.Errorf("unexpected soil data %+v", s)
+	}
+
+	if _, err := a.Soil(context.Background(), ""); err != errPolygonID {
+		t.Errorf("Expected %v, got %v", errPolygonID, err)
+	}
+}
+
+func TestAgroSoilHistory(t *testing.T) {
+	t.Parallel()
+
+	a := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/agro/1.0/soil/history" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("start") != "1522108800" || q.Get("end") != "1522195200" {
+			t.Errorf("unexpected query %v", q)
+		}
+		fmt.Fprint(w, `[{"dt":1522108800,"t10":281.96,"moisture":0.175,"t0":279.02},{"dt":1522152000,"t10":282.1,"moisture":0.171,"t0":283.5}]`)
+	}).Agro()
+
+	start := time.Unix(1522108800, 0)
+	s, err := a.SoilHistory(context.Background(), "p1", start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(s) != 2 || !almostEqual(s[1].T0, 283.5) {
+		t.Errorf("unexpected soil history %+v", s)
+	}
+
+	if _, err := a.SoilHistory(context.Background(), "p1", start, start); err != errInvalidTimeRange {
+		t.Errorf("Expected %v, got %v", errInvalidTimeRange, err)
+	}
+}
+
+const testPolygon = `{"id":"5aaa8052cbbbb5000b73ff66","geo_json":{"type":"Feature","properties":{},"geometry":{"type":"Polygon","coordinates":[[[-121.1958,37.6683],[-121.1779,37.6687],[-121.1773,37.6792],[-121.1958,37.6683]]]}},"name":"Field 1","center":[-121.1853,37.6725],"area":190.94,"user_id":"u1","created_at":1521172528}`
+
+func TestAgroPolygons(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var requests []string
+	a := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		mu.Unlock()
+
+		switch {
+		case r.Method == http.MethodPost:
+			var body struct {
+				Name    string `json:"name"`
+				GeoJSON struct {
+					Type     string      `json:"type"`
+					Geometry TriggerArea `json:"geometry"`
+				} `json:"geo_json"`
+			}
+			if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+				t.Error(err)
+			}
+			if body.Name != "Field 1" || body.GeoJSON.Type != "Feature" || body.GeoJSON.Geometry.Type != "Polygon" {
+				t.Errorf("unexpected polygon %+v", body)
+			}
+			fmt.Fprint(w, testPolygon)
+		case r.Method == http.MethodGet && r.URL.Path == "/agro/1.0/polygons":
+			fmt.Fprint(w, "["+testPolygon+"]")
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			fmt.Fprint(w, testPolygon)
+		}
+	}).Agro()
+	ctx := context.Background()
+
+	p, err := a.CreatePolygon(ctx, "Field 1",
+		Coordinates{Longitude: -121.1958, Latitude: 37.6683},
+		Coordinates{Longitude: -121.1779, Latitude: 37.6687},
+		Coordinates{Longitude: -121.1773, Latitude: 37.6792})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if p.ID != "5aaa8052cbbbb5000b73ff66" || !almostEqual(p.Area, 190.94) || !almostEqual(p.Center.Latitude, 37.6725) ||
+		p.CreatedAt.Unix() != 1521172528 || p.Geometry.Type != "Polygon" {
+		t.Errorf("unexpected polygon %+v", p)
+	}
+
+	list, err := a.Polygons(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Name != "Field 1" {
+		t.Errorf("unexpected polygons %+v", list)
+	}
+	if _, err := a.Polygon(ctx, p.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.RenamePolygon(ctx, p.ID, "Field 2"); err != nil {
+		t.Fatal(err)
+	}
+	if err := a.DeletePolygon(ctx, p.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"POST /agro/1.0/polygons",
+		"GET /agro/1.0/polygons",
+		"GET /agro/1.0/polygons/5aaa8052cbbbb5000b73ff66",
+		"PUT /agro/1.0/polygons/5aaa8052cbbbb5000b73ff66",
+		"DELETE /agro/1.0/polygons/5aaa8052cbbbb5000b73ff66",
+	}
+	if strings.Join(requests, "\n") != strings.Join(want, "\n") {
+		t.Errorf("Expected requests %v, got %v", want, requests)
+	}
+
+	if _, err := a.CreatePolygon(ctx, "Field 1", Coordinates{}, Coordinates{}); err != errInvalidPolygon {
+		t.Errorf("Expected %v, got %v", errInvalidPolygon, err)
+	}
+	if err := a.DeletePolygon(ctx, ""); err != errPolygonID {
+		t.Errorf("Expected %v, got %v", errPolygonID, err)
+	}
+}
+
+func TestAgroSearchImages(t *testing.T) {
+	t.Parallel()
+
+	a := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/agro/1.0/image/search":
+			q := r.URL.Query()
+			if q.Get("polyid") != "p1" || q.Get("clouds_max") != "20" || q.Get("type") != "s2" || q.Get("coverage_min") != "" {
+				t.Errorf("unexpected query %v", q)
+			}
+			fmt.Fprint(w, `[{"dt":1500249600,"type":"Sentinel-2","dc":100,"cl":1.56,"sun":{"elevation":64.3,"azimuth":134.9},
+				"image":{"truecolor":"http://api.agromonitoring.com/image/1.0/002/a?appid=k","ndvi":"http://api.agromonitoring.com/image/1.0/102/a?appid=k"},
+				"stats":{"ndvi":"http://api.agromonitoring.com/stats/1.0/02a?appid=k"}}]`)
+		case "/stats/1.0/02a":
+			fmt.Fprint(w, `{"std":0.136,"p25":0.135,"num":57158,"min":-0.194,"max":0.769,"median":0.242,"p75":0.351,"mean":0.259}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	}).Agro()
+	ctx := context.Background()
+
+	start := time.Unix(1500000000, 0)
+	images, err := a.SearchImages(ctx, "p1", ImageQuery{Start: start, End: start.Add(30 * 24 * time.Hour), MaxClouds: Float(20), Satellite: SatelliteSentinel2})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(images) != 1 || images[0].Satellite != "Sentinel-2" || !almostEqual(images[0].Clouds, 1.56) ||
+		!almostEqual(images[0].Sun.Elevation, 64.3) || images[0].Image.NDVI == "" {
+		t.Fatalf("unexpected images %+v", images)
+	}
+
+	s, err := a.IndexStats(ctx, images[0].Stats.NDVI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if s.Num != 57158 || !almostEqual(s.Mean, 0.259) || !almostEqual(s.P75, 0.351) {
+		t.Errorf("unexpected stats %+v", s)
+	}
+
+	if _, err := a.SearchImages(ctx, "p1", ImageQuery{Start: start, End: start}); err != errInvalidTimeRange {
+		t.Errorf("Expected %v, got %v", errInvalidTimeRange, err)
+	}
+}
@@ -0,0 +1,104 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"strconv"
+	"time"
+)
+
+var errInvalidTimeRange = errors.New("invalid time range")
+
+// airHistoryChunk is the longest time range requested from the air
+// pollution history at once.  Longer ranges are split into several
+// requests.
+const airHistoryChunk = 30 * 24 * time.Hour
+
+// AirComponents holds the concentrations, in μg/m³, of the pollutants
+// measured by the air pollution API.
+type AirComponents struct {
+	CO   float64 `json:"co"`
+	NO   float64 `json:"no"`
+	NO2  float64 `json:"no2"`
+	O3   float64 `json:"o3"`
+	SO2  float64 `json:"so2"`
+	PM25 float64 `json:"pm2_5"`
+	PM10 float64 `json:"pm10"`
+	NH3  float64 `json:"nh3"`
+}
+
+// AirPollutionEntry holds the air quality at one point in time.  AQI is
+// the air quality index from 1 (good) to 5 (very poor).
+type AirPollutionEntry struct {
+	Dt   Timestamp `json:"dt"`
+	Main struct {
+		AQI int `json:"aqi"`
+	} `json:"main"`
+	Components AirComponents `json:"components"`
+}
+
+// AirPollution holds the data returned by the air pollution API.
+type AirPollution struct {
+	Coord Coordinates         `json:"coord"`
+	List  []AirPollutionEntry `json:"list"`
+}
+
+// AirPollutionByCoordinates returns the current air pollution for the
+// provided location coordinates.
+func (c *Client) AirPollutionByCoordinates(ctx context.Context, location *Coordinates) (*AirPollution, error) {
+	a := &AirPollution{}
+	if err := c.get(ctx, c.dataURL("air_pollution")+"?"+c.query(coordinateParams(location)).Encode(), a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// AirPollutionHistory returns the hourly air pollution for the provided
+// location coordinates from start to end.  Data is available from 27th
+// November 2020.  Ranges longer than 30 days are fetched with several
+// requests and merged into one list.
+func (c *Client) AirPollutionHistory(ctx context.Context, location *Coordinates, start, end time.Time) (*AirPollution, error) {
+	if !start.Before(end) {
+		return nil, errInvalidTimeRange
+	}
+
+	a := &AirPollution{Coord: *location}
+	for from := start; from.Before(end); from = from.Add(airHistoryChunk) {
+		to := from.Add(airHistoryChunk)
+		if to.After(end) {
+			to = end
+		}
+
+		params := coordinateParams(location)
+		params.Set("start", strconv.FormatInt(from.Unix(), 10))
+		params.Set("end", strconv.FormatInt(to.Unix(), 10))
+
+		var chunk AirPollution
+		if err := c.get(ctx, c.dataURL("air_pollution/history")+"?"+c.query(params).Encode(), &chunk); err != nil {
+			return nil, err
+		}
+		a.Coord = chunk.Coord
+		for _, e := range chunk.List {
+			// Entries on the boundary between two chunks can be returned
+			// by both requests.
+			if n := len(a.List); n > 0 && !e.Dt.After(a.List[n-1].Dt.Time) {
+				continue
+			}
+			a.List = append(a.List, e)
+		}
+	}
+	return a, nil
+}
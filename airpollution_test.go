@@ -0,0 +1,111 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientAirPollutionByCoordinates(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/air_pollution" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("lat") != "50" || q.Get("lon") != "50" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"coord":{"lon":50,"lat":50},"list":[{"main":{"aqi":2},
+"components":{"co":201.94,"no":0.02,"no2":0.77,"o3":68.66,"so2":0.64,"pm2_5":12.5,"pm10":14.8,"nh3":0.12},"dt":1606147200}]}`)
+	}, WithStrictDecoding())
+
+	a, err := c.AirPollutionByCoordinates(context.Background(), &Coordinates{Latitude: 50, Longitude: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.Coord.Latitude != 50 || len(a.List) != 1 {
+		t.Fatalf("unexpected result %+v", a)
+	}
+	e := a.List[0]
+	if e.Dt.Unix() != 1606147200 || e.Main.AQI != 2 {
+		t.Errorf("unexpected entry %+v", e)
+	}
+	want := AirComponents{CO: 201.94, NO: 0.02, NO2: 0.77, O3: 68.66, SO2: 0.64, PM25: 12.5, PM10: 14.8, NH3: 0.12}
+	if e.Components != want {
+		t.Errorf("Expected components %+v, got %+v", want, e.Components)
+	}
+}
+
+func TestClientAirPollutionHistory(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var ranges [][2]int64
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/air_pollution/history" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		start, _ := strconv.ParseInt(r.URL.Query().Get("start"), 10, 64)
+		end, _ := strconv.ParseInt(r.URL.Query().Get("end"), 10, 64)
+		mu.Lock()
+		ranges = append(ranges, [2]int64{start, end})
+		mu.Unlock()
+
+		// One entry per day, including both ends of the range.
+		fmt.Fprint(w, `{"coord":{"lon":-0.13,"lat":51.51},"list":[`)
+		for dt := start; dt <= end; dt += 24 * 60 * 60 {
+			if dt != start {
+				fmt.Fprint(w, ",")
+			}
+			fmt.Fprintf(w, `{"dt":%d,"main":{"aqi":1},"components":{"pm10":%d}}`, dt, dt%7)
+		}
+		fmt.Fprint(w, `]}`)
+	})
+
+	start := time.Date(2021, time.January, 1, 0, 0, 0, 0, time.UTC)
+	end := start.AddDate(0, 0, 70)
+	a, err := c.AirPollutionHistory(context.Background(), &Coordinates{Latitude: 51.51, Longitude: -0.13}, start, end)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(ranges) != 3 {
+		t.Fatalf("Expected 3 requests, got %v", ranges)
+	}
+	if ranges[0][0] != start.Unix() || ranges[2][1] != end.Unix() || ranges[0][1] != ranges[1][0] {
+		t.Errorf("Expected consecutive chunks from start to end, got %v", ranges)
+	}
+	if len(a.List) != 71 {
+		t.Errorf("Expected 71 daily entries, got %d", len(a.List))
+	}
+	for i := 1; i < len(a.List); i++ {
+		if !a.List[i].Dt.After(a.List[i-1].Dt.Time) {
+			t.Fatalf("Expected ordered, unique entries, got %v after %v", a.List[i].Dt, a.List[i-1].Dt)
+		}
+	}
+	if a.Coord.Latitude != 51.51 {
+		t.Errorf("unexpected coordinates %+v", a.Coord)
+	}
+
+	if _, err := c.AirPollutionHistory(context.Background(), &Coordinates{}, end, start); err != errInvalidTimeRange {
+		t.Errorf("Expected %v, got %v", errInvalidTimeRange, err)
+	}
+}
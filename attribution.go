@@ -0,0 +1,94 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"errors"
+	"fmt"
+	"html"
+)
+
+var errInvalidPlacement = errors.New("invalid attribution placement")
+
+// Attribution and license of the data returned by the API.  OWM
+// requires applications displaying its data to credit OpenWeather and
+// link to its website; the data is licensed under CC BY-SA 4.0.
+const (
+	AttributionText = "Weather data provided by OpenWeather"
+	AttributionURL  = "https://openweathermap.org/"
+	LicenseName     = "CC BY-SA 4.0"
+	LicenseURL      = "https://creativecommons.org/licenses/by-sa/4.0/"
+)
+
+// Attribution returns the attribution and license as plain text, for
+// output such as feeds, logs and terminals.
+func Attribution() string {
+	return fmt.Sprintf("%s (%s), %s (%s)", AttributionText, AttributionURL, LicenseName, LicenseURL)
+}
+
+// AttributionHTML returns the attribution and license as an HTML
+// fragment with links, for web pages and widgets.
+func AttributionHTML() string {
+	return fmt.Sprintf(`<a href="%s">%s</a>, <a href="%s">%s</a>`,
+		html.EscapeString(AttributionURL), html.EscapeString(AttributionText),
+		html.EscapeString(LicenseURL), html.EscapeString(LicenseName))
+}
+
+// AttributionPlacement is where an exported artifact, such as the
+// report of WriteDegreeDaysCSV or a heat map, carries the attribution.
+type AttributionPlacement int
+
+// Attribution placements of WithAttribution.  Exports put the
+// attribution in their footer unless told otherwise.
+const (
+	AttributionFooter AttributionPlacement = iota
+	AttributionHeader
+	AttributionOff
+)
+
+// ExportOption configures the artifacts written by exports such as
+// WriteDegreeDaysCSV.
+type ExportOption func(e *exportSettings) error
+
+// exportSettings holds the settings of an export.
+type exportSettings struct {
+	attribution AttributionPlacement
+}
+
+// newExportSettings returns the settings with options applied.
+func newExportSettings(options []ExportOption) (*exportSettings, error) {
+	e := &exportSettings{attribution: AttributionFooter}
+	for _, o := range options {
+		if o == nil {
+			return nil, errInvalidOption
+		}
+		if err := o(e); err != nil {
+			return nil, err
+		}
+	}
+	return e, nil
+}
+
+// WithAttribution sets where the export puts the attribution.  Use
+// AttributionOff only when the data is credited some other way, e.g.
+// next to where the artifact is shown.
+func WithAttribution(p AttributionPlacement) ExportOption {
+	return func(e *exportSettings) error {
+		if p < AttributionFooter || p > AttributionOff {
+			return errInvalidPlacement
+		}
+		e.attribution = p
+		return nil
+	}
+}
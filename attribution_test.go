@@ -0,0 +1,35 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestAttribution(t *testing.T) {
+	t.Parallel()
+
+	for _, a := range []string{Attribution(), AttributionHTML()} {
+		for _, want := range []string{AttributionText, AttributionURL, LicenseName, LicenseURL} {
+			if !strings.Contains(a, want) {
+				t.Errorf("Expected %q to contain %q", a, want)
+			}
+		}
+	}
+
+	if want := `<a href="https://openweathermap.org/">Weather data provided by OpenWeather</a>`; !strings.HasPrefix(AttributionHTML(), want) {
+		t.Errorf("Expected a link to OpenWeather, got %s", AttributionHTML())
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"time"
+)
+
+// DefaultBatchInterval is the time batch operations such as GeocodeAll
+// and SampleGrid wait between requests by default, which keeps them
+// within the 60 calls per minute of the free plan.  It can be changed
+// with WithBatchInterval.
+const DefaultBatchInterval = time.Second
+
+// WithBatchInterval sets the time batch operations wait between
+// requests.  Zero sends requests back to back.
+func WithBatchInterval(d time.Duration) Option {
+	return func(s *Settings) error {
+		if d < 0 {
+			return errInvalidOption
+		}
+		s.batchInterval = d
+		return nil
+	}
+}
+
+// throttle spaces the requests of one batch operation.
+type throttle struct {
+	interval time.Duration
+	last     time.Time
+}
+
+func (c *Client) newThrottle() *throttle {
+	return &throttle{interval: c.batchInterval}
+}
+
+// wait blocks until the next request may be sent.  It returns the
+// context error if ctx is done first.
+func (t *throttle) wait(ctx context.Context) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if wait := t.interval - time.Since(t.last); !t.last.IsZero() && wait > 0 {
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+	t.last = time.Now()
+	return nil
+}
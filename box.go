@@ -0,0 +1,65 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+var errInvalidBox = errors.New("invalid bounding box or zoom")
+var errAreaUnsupported = errors.New("area queries need Client.CurrentByBox")
+
+// BoxCityData holds the current weather for the cities inside a
+// bounding box, as returned by the /box/city endpoint.
+type BoxCityData struct {
+	COD      int                  `json:"cod"`
+	Calctime float64              `json:"calctime"`
+	Cnt      int                  `json:"cnt"`
+	List     []CurrentWeatherData `json:"list"`
+	Unit     string
+	Lang     string
+}
+
+// CurrentByBox returns the current weather for the cities inside box.
+// zoom is the map zoom level, which decides how many cities are
+// returned: the higher, the more small cities.  The Unit and Lang of
+// every city are set like those of the result.
+func (c *Client) CurrentByBox(ctx context.Context, box BoundingBox, zoom int) (*BoxCityData, error) {
+	if box.South >= box.North || box.West >= box.East || zoom < 0 {
+		return nil, errInvalidBox
+	}
+	var bbox []string
+	for _, v := range []float64{box.West, box.South, box.East, box.North} {
+		bbox = append(bbox, strconv.FormatFloat(v, 'f', -1, 64))
+	}
+	bbox = append(bbox, strconv.Itoa(zoom))
+	params := url.Values{"bbox": {strings.Join(bbox, ",")}}
+
+	b := &BoxCityData{
+		Unit: c.unit,
+		Lang: c.lang,
+	}
+	if err := c.get(ctx, c.dataURL("box/city")+"?"+c.query(params).Encode(), b); err != nil {
+		return nil, err
+	}
+	for i := range b.List {
+		b.List[i].Unit = c.unit
+		b.List[i].Lang = c.lang
+	}
+	return b, nil
+}
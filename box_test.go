@@ -0,0 +1,53 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClientCurrentByBox(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/box/city" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.URL.Query().Get("bbox"); got != "12,32,15,37,10" {
+			t.Errorf("Expected bbox 12,32,15,37,10, got %s", got)
+		}
+		fmt.Fprint(w, `{"cod":200,"calctime":0.3107,"cnt":2,"list":[
+			{"id":2208791,"dt":1600000000,"name":"Yafran","coord":{"Lon":12.52859,"Lat":32.06329},"main":{"temp":9.68,"humidity":86},"wind":{"speed":4.2,"deg":306},"clouds":{"today":0},"weather":[{"id":800,"main":"Clear","description":"clear sky","icon":"01n"}]},
+			{"id":2208425,"dt":1600000000,"name":"Zuwarah","coord":{"Lon":12.08199,"Lat":32.931198},"main":{"temp":15.36,"humidity":89},"wind":{"speed":5.46,"deg":30},"clouds":{"today":56},"weather":[]}
+		]}`)
+	})
+
+	b, err := c.CurrentByBox(context.Background(), BoundingBox{South: 32, West: 12, North: 37, East: 15}, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if b.Cnt != 2 || len(b.List) != 2 {
+		t.Fatalf("Expected 2 cities, got %+v", b)
+	}
+	if city := b.List[0]; city.Name != "Yafran" || city.GeoPos.Latitude != 32.06329 || city.Main.Temp != 9.68 || city.Unit != "metric" {
+		t.Errorf("unexpected city %+v", city)
+	}
+
+	if _, err := c.CurrentByBox(context.Background(), BoundingBox{South: 37, West: 12, North: 32, East: 15}, 10); err != errInvalidBox {
+		t.Errorf("Expected %v, got %v", errInvalidBox, err)
+	}
+}
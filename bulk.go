@@ -0,0 +1,239 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+var errBulkFormat = errors.New("unrecognized History Bulk format")
+
+// BulkRecord is one hour of a History Bulk export: the same
+// WeatherHistory the history API returns with the location it's for.
+type BulkRecord struct {
+	CityName  string  `json:"city_name"`
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+	// Timezone is the offset of the local time from UTC, in seconds.
+	Timezone int `json:"timezone"`
+	WeatherHistory
+}
+
+// BulkReader reads the records of a History Bulk export, in its JSON or
+// CSV format, optionally gzip compressed, one at a time so exports of
+// any size can be read.
+type BulkReader struct {
+	json   *json.Decoder
+	csv    *csv.Reader
+	header []string
+	row    int
+}
+
+// NewBulkReader returns a BulkReader reading the History Bulk export
+// from r.  The format and compression are detected from the data.
+func NewBulkReader(r io.Reader) (*BulkReader, error) {
+	br, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+
+	first, err := firstByte(br)
+	if err != nil {
+		return nil, err
+	}
+	if first == '[' {
+		d := json.NewDecoder(br)
+		if _, err := d.Token(); err != nil {
+			return nil, err
+		}
+		return &BulkReader{json: d}, nil
+	}
+
+	c := csv.NewReader(br)
+	c.ReuseRecord = true
+	header, err := c.Read()
+	if err != nil {
+		return nil, errBulkFormat
+	}
+	b := &BulkReader{csv: c, header: append([]string(nil), header...)}
+	for _, h := range b.header {
+		if h == "dt" {
+			return b, nil
+		}
+	}
+	return nil, errBulkFormat
+}
+
+// decompress returns a reader of the data of r, which is gunzipped if
+// it's gzip compressed.
+func decompress(r io.Reader) (*bufio.Reader, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		br = bufio.NewReader(zr)
+	}
+	return br, nil
+}
+
+// firstByte returns the first byte of r that isn't white space or a
+// byte order mark, leaving it unread.
+func firstByte(r *bufio.Reader) (byte, error) {
+	if bom, err := r.Peek(3); err == nil && bytes.Equal(bom, []byte{0xef, 0xbb, 0xbf}) {
+		r.Discard(3)
+	}
+	for {
+		b, err := r.ReadByte()
+		if err == io.EOF {
+			return 0, errBulkFormat
+		}
+		if err != nil {
+			return 0, err
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		}
+		return b, r.UnreadByte()
+	}
+}
+
+// Next returns the next record of the export, or io.EOF after the last
+// one.
+func (b *BulkReader) Next() (*BulkRecord, error) {
+	if b.json != nil {
+		if !b.json.More() {
+			return nil, io.EOF
+		}
+		rec := &BulkRecord{}
+		if err := b.json.Decode(rec); err != nil {
+			return nil, err
+		}
+		return rec, nil
+	}
+
+	row, err := b.csv.Read()
+	if err != nil {
+		return nil, err
+	}
+	b.row++
+	rec := &BulkRecord{}
+	var w Weather
+	for i, v := range row {
+		if v == "" || i >= len(b.header) {
+			continue
+		}
+		if err := setBulkField(rec, &w, b.header[i], v); err != nil {
+			return nil, fmt.Errorf("bulk record %d, %s: %v", b.row, b.header[i], err)
+		}
+	}
+	if w != (Weather{}) {
+		rec.Weather = []Weather{w}
+	}
+	return rec, nil
+}
+
+// setBulkField sets the field of rec, or of its weather condition w,
+// of the CSV column named column to v.  Unknown columns are ignored.
+func setBulkField(rec *BulkRecord, w *Weather, column, v string) error {
+	var f *float64
+	switch column {
+	case "city_name":
+		rec.CityName = v
+		return nil
+	case "weather_main":
+		w.Main = v
+		return nil
+	case "weather_description":
+		w.Description = v
+		return nil
+	case "weather_icon":
+		w.Icon = v
+		return nil
+	case "dt":
+		n, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			return err
+		}
+		rec.Dt = TimestampFromUnix(n)
+		return nil
+	case "timezone", "humidity", "clouds_all", "weather_id":
+		n, err := strconv.Atoi(v)
+		if err != nil {
+			return err
+		}
+		switch column {
+		case "timezone":
+			rec.Timezone = n
+		case "humidity":
+			rec.Main.Humidity = n
+		case "clouds_all":
+			rec.Clouds.All = n
+		case "weather_id":
+			w.ID = n
+		}
+		return nil
+	case "lat":
+		f = &rec.Latitude
+	case "lon":
+		f = &rec.Longitude
+	case "temp":
+		f = &rec.Main.Temp
+	case "feels_like":
+		f = &rec.Main.FeelsLike
+	case "temp_min":
+		f = &rec.Main.TempMin
+	case "temp_max":
+		f = &rec.Main.TempMax
+	case "pressure":
+		f = &rec.Main.Pressure
+	case "sea_level":
+		f = &rec.Main.SeaLevel
+	case "grnd_level":
+		f = &rec.Main.GrndLevel
+	case "wind_speed":
+		f = &rec.Wind.Speed
+	case "wind_deg":
+		f = &rec.Wind.Deg
+	case "wind_gust":
+		f = &rec.Wind.Gust
+	case "rain_1h":
+		f = &rec.Rain.OneH
+	case "rain_3h":
+		f = &rec.Rain.ThreeH
+	case "snow_1h":
+		f = &rec.Snow.OneH
+	case "snow_3h":
+		f = &rec.Snow.ThreeH
+	default:
+		return nil
+	}
+	n, err := strconv.ParseFloat(v, 64)
+	if err != nil {
+		return err
+	}
+	*f = n
+	return nil
+}
@@ -0,0 +1,120 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"strings"
+	"testing"
+)
+
+const testBulkJSON = `[
+{"city_name":"Berlin","lat":52.52,"lon":13.405,"main":{"temp":3.1,"temp_min":2.5,"temp_max":3.9,"feels_like":-0.4,"pressure":1012,"humidity":86,"dew_point":0.9},"wind":{"speed":3.6,"deg":240},"clouds":{"all":90},"weather":[{"id":500,"main":"Rain","description":"light rain","icon":"10n"}],"rain":{"1h":0.3},"dt":1577836800,"dt_iso":"2020-01-01 00:00:00 +0000 UTC","timezone":3600},
+{"city_name":"Berlin","lat":52.52,"lon":13.405,"main":{"temp":2.7,"humidity":88,"pressure":1013},"wind":{"speed":3.1,"deg":250},"clouds":{"all":75},"weather":[{"id":803,"main":"Clouds","description":"broken clouds","icon":"04n"}],"snow":{"1h":0.1},"dt":1577840400,"timezone":3600}
+]`
+
+const testBulkCSV = "\xef\xbb\xbfdt,dt_iso,timezone,city_name,lat,lon,temp,visibility,dew_point,feels_like,temp_min,temp_max,pressure,sea_level,grnd_level,humidity,wind_speed,wind_deg,wind_gust,rain_1h,rain_3h,snow_1h,snow_3h,clouds_all,weather_id,weather_main,weather_description,weather_icon\n" +
+	"1577836800,2020-01-01 00:00:00 +0000 UTC,3600,Berlin,52.52,13.405,3.1,,0.9,-0.4,2.5,3.9,1012,,,86,3.6,240,,0.3,,,,90,500,Rain,light rain,10n\n" +
+	"1577840400,2020-01-01 01:00:00 +0000 UTC,3600,Berlin,52.52,13.405,2.7,,,,,,1013,,,88,3.1,250,,,,0.1,,75,803,Clouds,broken clouds,04n\n"
+
+func readBulk(t *testing.T, r io.Reader) []*BulkRecord {
+	t.Helper()
+
+	b, err := NewBulkReader(r)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var recs []*BulkRecord
+	for {
+		rec, err := b.Next()
+		if err == io.EOF {
+			return recs
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		recs = append(recs, rec)
+	}
+}
+
+func gzipped(t *testing.T, s string) io.Reader {
+	t.Helper()
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(w, s); err != nil {
+		t.Fatal(err)
+	}
+	if err := w.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestBulkReader(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		r    func() io.Reader
+	}{
+		{"json", func() io.Reader { return strings.NewReader(testBulkJSON) }},
+		{"csv", func() io.Reader { return strings.NewReader(testBulkCSV) }},
+		{"json.gz", func() io.Reader { return gzipped(t, testBulkJSON) }},
+		{"csv.gz", func() io.Reader { return gzipped(t, testBulkCSV) }},
+	}
+	for _, tt := range tests {
+		recs := readBulk(t, tt.r())
+		if len(recs) != 2 {
+			t.Fatalf("%s: expected 2 records, got %d", tt.name, len(recs))
+		}
+		r := recs[0]
+		if r.CityName != "Berlin" || !almostEqual(r.Latitude, 52.52) || !almostEqual(r.Longitude, 13.405) || r.Timezone != 3600 {
+			t.Errorf("%s: unexpected location %+v", tt.name, r)
+		}
+		if r.Dt.Unix() != 1577836800 || !almostEqual(r.Main.Temp, 3.1) || !almostEqual(r.Main.FeelsLike, -0.4) || r.Main.Humidity != 86 {
+			t.Errorf("%s: unexpected main %+v", tt.name, r.Main)
+		}
+		if !almostEqual(r.Wind.Speed, 3.6) || r.Clouds.All != 90 || !almostEqual(r.Rain.OneH, 0.3) {
+			t.Errorf("%s: unexpected record %+v", tt.name, r.WeatherHistory)
+		}
+		if len(r.Weather) != 1 || r.Weather[0] != (Weather{ID: 500, Main: "Rain", Description: "light rain", Icon: "10n"}) {
+			t.Errorf("%s: unexpected weather %+v", tt.name, r.Weather)
+		}
+		if !almostEqual(recs[1].Snow.OneH, 0.1) || recs[1].Main.TempMin != 0 {
+			t.Errorf("%s: unexpected second record %+v", tt.name, recs[1].WeatherHistory)
+		}
+	}
+}
+
+func TestBulkReaderErrors(t *testing.T) {
+	t.Parallel()
+
+	for _, in := range []string{"", "   ", "name,temp\nBerlin,3\n"} {
+		if _, err := NewBulkReader(strings.NewReader(in)); err != errBulkFormat {
+			t.Errorf("%q: expected %v, got %v", in, errBulkFormat, err)
+		}
+	}
+
+	b, err := NewBulkReader(strings.NewReader("dt,temp\n1577836800,warm\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := b.Next(); err == nil || !strings.Contains(err.Error(), "temp") {
+		t.Errorf("Expected an error naming the column, got %v", err)
+	}
+}
@@ -0,0 +1,110 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"time"
+)
+
+// Capabilities reports which endpoints the plan of the API key gives
+// access to.
+type Capabilities struct {
+	Forecast5  bool // 5 day forecast in 3 hour steps
+	Forecast16 bool // 16 day daily forecast
+	Hourly     bool // 4 day hourly forecast
+	Climate    bool // 30 day climate forecast
+	OneCall    bool // One Call API 3.0
+	History    bool // hourly history
+}
+
+// ForecastHorizon returns how far ahead the available forecasts reach,
+// or 0 if none is available.
+func (c *Capabilities) ForecastHorizon() time.Duration {
+	const day = 24 * time.Hour
+	switch {
+	case c.Climate:
+		return 30 * day
+	case c.Forecast16:
+		return 16 * day
+	case c.OneCall:
+		return 8 * day
+	case c.Forecast5:
+		return 5 * day
+	case c.Hourly:
+		return 4 * day
+	}
+	return 0
+}
+
+// Capabilities probes which endpoints the API key has access to, with a
+// minimal request to each, spaced by the batch interval.  The result is
+// cached by the Client, so only the first call sends requests.  When
+// several keys are rotated, they are assumed to be on the same plan, and
+// an endpoint refusing a key doesn't make the rotation skip it.  Errors
+// other than an endpoint refusing the key, e.g. network errors,
+// are returned and nothing is cached.
+func (c *Client) Capabilities(ctx context.Context) (*Capabilities, error) {
+	c.capMu.Lock()
+	defer c.capMu.Unlock()
+	if c.caps != nil {
+		caps := *c.caps
+		return &caps, nil
+	}
+
+	// The refusals are expected, so they mustn't block the keys.
+	ctx = withoutKeyBackoff(ctx)
+	loc := &Coordinates{}
+	caps := &Capabilities{}
+	probes := []struct {
+		available *bool
+		probe     func() error
+	}{
+		{&caps.Forecast5, func() error { _, err := c.Forecast5ByCoordinates(ctx, loc, 1); return err }},
+		{&caps.Forecast16, func() error { _, err := c.Forecast16ByCoordinates(ctx, loc, 1); return err }},
+		{&caps.Hourly, func() error { _, err := c.ForecastHourlyByCoordinates(ctx, loc, 1); return err }},
+		{&caps.Climate, func() error { _, err := c.ClimateForecastByCoordinates(ctx, loc, 1); return err }},
+		{&caps.OneCall, func() error {
+			_, err := c.OneCall(ctx, loc, OneCallBlockMinutely, OneCallBlockHourly, OneCallBlockDaily, OneCallBlockAlerts)
+			return err
+		}},
+		{&caps.History, func() error {
+			_, err := c.HistoryByCoordinates(ctx, loc, HistoryQuery{Start: time.Now().Add(-2 * time.Hour), Cnt: 1})
+			return err
+		}},
+	}
+
+	th := c.newThrottle()
+	for _, p := range probes {
+		if err := th.wait(ctx); err != nil {
+			return nil, err
+		}
+		err := p.probe()
+		if err != nil && !refused(err) {
+			return nil, err
+		}
+		*p.available = err == nil
+	}
+
+	c.caps = caps
+	result := *caps
+	return &result, nil
+}
+
+// refused reports whether err is the API refusing the key access to an
+// endpoint.
+func refused(err error) bool {
+	apiErr, ok := err.(*APIError)
+	return ok && (apiErr.COD == "401" || apiErr.COD == "403")
+}
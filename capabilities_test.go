@@ -0,0 +1,103 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientCapabilities(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		if strings.HasPrefix(r.URL.Path, "/data/2.5/forecast") && r.URL.Host != "pro.openweathermap.org" {
+			fmt.Fprint(w, `{"cod":"200","list":[]}`)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"cod":401,"message":"Invalid API key"}`)
+	}, WithBatchInterval(0))
+
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Capabilities{Forecast5: true, Forecast16: true}
+	if *caps != want {
+		t.Errorf("Expected %+v, got %+v", want, *caps)
+	}
+	if caps.ForecastHorizon() != 16*24*time.Hour {
+		t.Errorf("Expected a 16 day horizon, got %v", caps.ForecastHorizon())
+	}
+
+	if _, err := c.Capabilities(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	if n := atomic.LoadInt32(&requests); n != 6 {
+		t.Errorf("Expected the capabilities to be probed once with 6 requests, got %d", n)
+	}
+}
+
+func TestClientCapabilitiesKeyPool(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/data/2.5/weather" {
+			fmt.Fprint(w, `{"name":"Dublin"}`)
+			return
+		}
+		if strings.HasPrefix(r.URL.Path, "/data/2.5/forecast") && r.URL.Host != "pro.openweathermap.org" {
+			fmt.Fprint(w, `{"cod":"200","list":[]}`)
+			return
+		}
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"cod":401,"message":"Invalid API key"}`)
+	}, WithBatchInterval(0), WithAPIKeys(RoundRobin, poolKeys[:2]...))
+
+	caps, err := c.Capabilities(context.Background())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := (Capabilities{Forecast5: true, Forecast16: true}); *caps != want {
+		t.Errorf("Expected %+v, got %+v", want, *caps)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.CurrentByName(context.Background(), "Dublin"); err != nil {
+			t.Fatalf("Expected the keys to stay available after probing, got %v", err)
+		}
+	}
+}
+
+func TestClientCapabilitiesError(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}, WithBatchInterval(0))
+
+	if _, err := c.Capabilities(context.Background()); err == nil {
+		t.Error("Expected the server error")
+	}
+	if c.caps != nil {
+		t.Error("Expected nothing to be cached after an error")
+	}
+}
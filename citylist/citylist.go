@@ -0,0 +1,354 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package citylist indexes OWM's city.list.json, the list of the cities
+// the API knows with their IDs, so city IDs can be resolved offline by
+// name, with a typo tolerant search, or from coordinates.
+// The list is available from http://bulk.openweathermap.org/sample/.
+//
+//	idx, err := citylist.LoadFile("city.list.json.gz")
+//	for _, c := range idx.ByName("Springfield", "US") {
+//		w, err := client.CurrentByID(ctx, c.ID)
+//	}
+//	nearest, km, ok := idx.NearestCity(lat, lon)
+//
+// Servers that don't want to load the list at start up use a Lazy, which
+// loads it on first use or when Preload is called.
+package citylist
+
+import (
+	"bufio"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+
+	owm "github.com/briandowns/openweathermap"
+)
+
+// City is an entry of the city list.
+type City struct {
+	ID      int             `json:"id"`
+	Name    string          `json:"name"`
+	State   string          `json:"state"`
+	Country string          `json:"country"`
+	Coord   owm.Coordinates `json:"coord"`
+}
+
+// Index looks cities up by ID and name.  It's safe for concurrent use
+// once loaded.
+type Index struct {
+	cities []City
+	byID   map[int]int
+	byName map[string][]int
+	// points holds the positions of the cities on the unit sphere and
+	// tree their indices ordered as a k-d tree: the median of every
+	// range along its axis is at the middle of the range.
+	points [][3]float64
+	tree   []int
+}
+
+// Load reads a city list, which may be gzip compressed, from r and
+// indexes it.  The list is decoded one city at a time.
+func Load(r io.Reader) (*Index, error) {
+	br := bufio.NewReader(r)
+	if magic, err := br.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		zr, err := gzip.NewReader(br)
+		if err != nil {
+			return nil, err
+		}
+		defer zr.Close()
+		br = bufio.NewReader(zr)
+	}
+
+	d := json.NewDecoder(br)
+	if _, err := d.Token(); err != nil {
+		return nil, err
+	}
+	idx := &Index{
+		byID:   map[int]int{},
+		byName: map[string][]int{},
+	}
+	for d.More() {
+		var c City
+		if err := d.Decode(&c); err != nil {
+			return nil, err
+		}
+		idx.add(c)
+	}
+	idx.tree = make([]int, len(idx.cities))
+	for n := range idx.tree {
+		idx.tree[n] = n
+	}
+	idx.build(idx.tree, 0)
+	return idx, nil
+}
+
+// LoadFile reads and indexes the city list in the named file.
+func LoadFile(name string) (*Index, error) {
+	f, err := os.Open(name)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Lazy loads the city list in a file once, on first use, so servers can
+// start without waiting for it or load it when they choose with Preload.
+// It's safe for concurrent use.
+type Lazy struct {
+	name string
+	once sync.Once
+	done chan struct{}
+	idx  *Index
+	err  error
+}
+
+// NewLazy returns a Lazy loading the city list in the named file.
+// Nothing is read until Preload or Index is called.
+func NewLazy(name string) *Lazy {
+	return &Lazy{name: name, done: make(chan struct{})}
+}
+
+// Preload starts loading the city list unless it already is and waits
+// for it.  If ctx is done first its error is returned and the list keeps
+// loading in the background for later calls.  A failed load isn't
+// retried.
+func (l *Lazy) Preload(ctx context.Context) error {
+	_, err := l.Index(ctx)
+	return err
+}
+
+// Index returns the index of the city list, loading it on the first
+// call, see Preload.
+func (l *Lazy) Index(ctx context.Context) (*Index, error) {
+	l.once.Do(func() {
+		go func() {
+			l.idx, l.err = LoadFile(l.name)
+			close(l.done)
+		}()
+	})
+	select {
+	case <-l.done:
+		return l.idx, l.err
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+// add adds c to the index.
+func (i *Index) add(c City) {
+	n := len(i.cities)
+	i.cities = append(i.cities, c)
+	i.byID[c.ID] = n
+	key := strings.ToLower(c.Name)
+	i.byName[key] = append(i.byName[key], n)
+	i.points = append(i.points, point(c.Coord.Latitude, c.Coord.Longitude))
+}
+
+// Len returns the number of cities in the index.
+func (i *Index) Len() int {
+	return len(i.cities)
+}
+
+// ByID returns the city with the given ID.
+func (i *Index) ByID(id int) (City, bool) {
+	n, ok := i.byID[id]
+	if !ok {
+		return City{}, false
+	}
+	return i.cities[n], true
+}
+
+// ByName returns the cities named name, ignoring case, in the order of
+// the list.  country, an ISO 3166 country code, limits the cities to
+// that country unless it's empty.
+func (i *Index) ByName(name, country string) []City {
+	var cities []City
+	for _, n := range i.byName[strings.ToLower(name)] {
+		if c := i.cities[n]; country == "" || strings.EqualFold(c.Country, country) {
+			cities = append(cities, c)
+		}
+	}
+	return cities
+}
+
+// earthRadius is the mean radius of the Earth in kilometers.
+const earthRadius = 6371.0
+
+// point returns the position of lat and lon on the unit sphere.  The
+// straight distance between two points grows with their distance on
+// the sphere, so the nearest point is the nearest city.
+func point(lat, lon float64) [3]float64 {
+	rlat, rlon := lat*math.Pi/180, lon*math.Pi/180
+	return [3]float64{math.Cos(rlat) * math.Cos(rlon), math.Cos(rlat) * math.Sin(rlon), math.Sin(rlat)}
+}
+
+// build orders the cities of t as a k-d tree splitting on axis first.
+func (i *Index) build(t []int, axis int) {
+	if len(t) < 2 {
+		return
+	}
+	sort.Slice(t, func(a, b int) bool { return i.points[t[a]][axis] < i.points[t[b]][axis] })
+	m := len(t) / 2
+	i.build(t[:m], (axis+1)%3)
+	i.build(t[m+1:], (axis+1)%3)
+}
+
+// NearestCity returns the city nearest to lat and lon and its distance
+// in kilometers, or false if the index is empty.
+func (i *Index) NearestCity(lat, lon float64) (City, float64, bool) {
+	if len(i.tree) == 0 {
+		return City{}, 0, false
+	}
+	p := point(lat, lon)
+	best, bestDist := -1, math.Inf(1)
+	i.nearest(i.tree, 0, p, &best, &bestDist)
+
+	chord := math.Sqrt(bestDist)
+	return i.cities[best], 2 * earthRadius * math.Asin(math.Min(chord/2, 1)), true
+}
+
+// nearest searches the k-d tree t, split on axis, for the point nearest
+// to p, updating best and bestDist, the squared distance to it.
+func (i *Index) nearest(t []int, axis int, p [3]float64, best *int, bestDist *float64) {
+	if len(t) == 0 {
+		return
+	}
+	m := len(t) / 2
+	q := i.points[t[m]]
+	var d float64
+	for k := range p {
+		d += (p[k] - q[k]) * (p[k] - q[k])
+	}
+	if d < *bestDist {
+		*best, *bestDist = t[m], d
+	}
+
+	near, far := t[:m], t[m+1:]
+	diff := p[axis] - q[axis]
+	if diff > 0 {
+		near, far = far, near
+	}
+	next := (axis + 1) % 3
+	i.nearest(near, next, p, best, bestDist)
+	if diff*diff < *bestDist {
+		i.nearest(far, next, p, best, bestDist)
+	}
+}
+
+// Match is a city found by Search.  Distance is the number of edits
+// turning the query into the start of the city's name; 0 for names the
+// query is the start of.
+type Match struct {
+	City
+	Distance int
+}
+
+// Search returns up to limit cities whose names start with query,
+// ignoring case and allowing one typo per four characters, such as
+// "philadelph" or "philedelphia" for Philadelphia.  The best matches
+// come first: the fewest edits, then the names closest in length to
+// query.
+func (i *Index) Search(query string, limit int) []Match {
+	q := []rune(strings.ToLower(strings.TrimSpace(query)))
+	if len(q) == 0 || limit < 1 {
+		return nil
+	}
+	maxDist := len(q) / 4
+
+	type candidate struct {
+		name string
+		dist int
+		diff int
+	}
+	var found []candidate
+	for name := range i.byName {
+		if d := prefixDistance(q, []rune(name), maxDist); d <= maxDist {
+			diff := len([]rune(name)) - len(q)
+			if diff < 0 {
+				diff = -diff
+			}
+			found = append(found, candidate{name, d, diff})
+		}
+	}
+	sort.Slice(found, func(a, b int) bool {
+		if found[a].dist != found[b].dist {
+			return found[a].dist < found[b].dist
+		}
+		if found[a].diff != found[b].diff {
+			return found[a].diff < found[b].diff
+		}
+		return found[a].name < found[b].name
+	})
+
+	var matches []Match
+	for _, c := range found {
+		for _, n := range i.byName[c.name] {
+			if len(matches) == limit {
+				return matches
+			}
+			matches = append(matches, Match{City: i.cities[n], Distance: c.dist})
+		}
+	}
+	return matches
+}
+
+// prefixDistance returns the smallest edit distance between q and a
+// prefix of name, or more than maxDist once it can't be maxDist or less.
+func prefixDistance(q, name []rune, maxDist int) int {
+	// row[j] is the distance between the query so far and name[:j].
+	row := make([]int, len(name)+1)
+	for j := range row {
+		row[j] = j
+	}
+	for i := 1; i <= len(q); i++ {
+		prev := row[0]
+		row[0] = i
+		rowMin := row[0]
+		for j := 1; j <= len(name); j++ {
+			cost := 1
+			if q[i-1] == name[j-1] {
+				cost = 0
+			}
+			d := prev + cost
+			if row[j]+1 < d {
+				d = row[j] + 1
+			}
+			if row[j-1]+1 < d {
+				d = row[j-1] + 1
+			}
+			prev, row[j] = row[j], d
+			if d < rowMin {
+				rowMin = d
+			}
+		}
+		if rowMin > maxDist {
+			return rowMin
+		}
+	}
+
+	best := row[0]
+	for _, d := range row[1:] {
+		if d < best {
+			best = d
+		}
+	}
+	return best
+}
@@ -0,0 +1,263 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package citylist
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"math"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const testList = `[
+  {"id": 4951788, "name": "Springfield", "state": "MA", "country": "US", "coord": {"lon": -72.589821, "lat": 42.101479}},
+  {"id": 4409896, "name": "Springfield", "state": "MO", "country": "US", "coord": {"lon": -93.298241, "lat": 37.21533}},
+  {"id": 2636921, "name": "Springfield", "state": "", "country": "GB", "coord": {"lon": -2.1667, "lat": 56.0}},
+  {"id": 2950159, "name": "Berlin", "state": "", "country": "DE", "coord": {"lon": 13.41053, "lat": 52.524368}}
+]`
+
+func TestLoad(t *testing.T) {
+	t.Parallel()
+
+	idx, err := Load(strings.NewReader(testList))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx.Len() != 4 {
+		t.Errorf("Expected 4 cities, got %d", idx.Len())
+	}
+
+	c, ok := idx.ByID(2950159)
+	if !ok || c.Name != "Berlin" || c.Country != "DE" || c.Coord.Latitude != 52.524368 {
+		t.Errorf("unexpected city %+v", c)
+	}
+	if _, ok := idx.ByID(1); ok {
+		t.Error("Expected no city with ID 1")
+	}
+
+	if got := idx.ByName("springfield", ""); len(got) != 3 {
+		t.Errorf("Expected 3 Springfields, got %+v", got)
+	}
+	got := idx.ByName("Springfield", "us")
+	if len(got) != 2 || got[0].State != "MA" || got[1].State != "MO" {
+		t.Errorf("Expected the 2 US Springfields in order, got %+v", got)
+	}
+	if got := idx.ByName("Spring", ""); len(got) != 0 {
+		t.Errorf("Expected only exact matches, got %+v", got)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	w.Write([]byte(testList))
+	w.Close()
+	dir, err := ioutil.TempDir("", "citylist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "city.list.json.gz")
+	if err := ioutil.WriteFile(name, buf.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := LoadFile(name)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if idx.Len() != 4 {
+		t.Errorf("Expected 4 cities, got %d", idx.Len())
+	}
+
+	if _, err := Load(strings.NewReader(`[{"id": "x"}]`)); err == nil {
+		t.Error("Expected an error for a malformed list")
+	}
+}
+
+func TestNearestCity(t *testing.T) {
+	t.Parallel()
+
+	idx, err := Load(strings.NewReader(testList))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	c, d, ok := idx.NearestCity(52.52, 13.4)
+	if !ok || c.ID != 2950159 || d > 1 {
+		t.Errorf("Expected Berlin within 1 km, got %+v at %.1f km", c, d)
+	}
+	c, d, ok = idx.NearestCity(42.1, -72.6)
+	if !ok || c.ID != 4951788 || d > 1 {
+		t.Errorf("Expected Springfield, MA, got %+v at %.1f km", c, d)
+	}
+	if _, d, _ := idx.NearestCity(42.101479, 13.41053); math.Abs(d-1156) > 10 {
+		t.Errorf("Expected Berlin about 1156 km away, got %.1f km", d)
+	}
+
+	empty, err := Load(strings.NewReader("[]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, _, ok := empty.NearestCity(0, 0); ok {
+		t.Error("Expected no city in an empty index")
+	}
+}
+
+func TestNearestCityMatchesBruteForce(t *testing.T) {
+	t.Parallel()
+
+	r := rand.New(rand.NewSource(1))
+	var b strings.Builder
+	b.WriteString("[")
+	for n := 0; n < 2000; n++ {
+		if n > 0 {
+			b.WriteString(",")
+		}
+		fmt.Fprintf(&b, `{"id":%d,"name":"c%d","coord":{"lon":%f,"lat":%f}}`, n, n, r.Float64()*360-180, r.Float64()*180-90)
+	}
+	b.WriteString("]")
+	idx, err := Load(strings.NewReader(b.String()))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for n := 0; n < 200; n++ {
+		lat, lon := r.Float64()*180-90, r.Float64()*360-180
+		got, _, _ := idx.NearestCity(lat, lon)
+
+		p := point(lat, lon)
+		want, wantDist := -1, math.Inf(1)
+		for i, q := range idx.points {
+			var d float64
+			for k := range p {
+				d += (p[k] - q[k]) * (p[k] - q[k])
+			}
+			if d < wantDist {
+				want, wantDist = i, d
+			}
+		}
+		if got.ID != idx.cities[want].ID {
+			t.Fatalf("%f, %f: expected city %d, got %d", lat, lon, idx.cities[want].ID, got.ID)
+		}
+	}
+}
+
+func TestSearch(t *testing.T) {
+	t.Parallel()
+
+	idx, err := Load(strings.NewReader(`[
+		{"id": 4560349, "name": "Philadelphia", "country": "US", "coord": {"lon": -75.163788, "lat": 39.952339}},
+		{"id": 5205388, "name": "Philadelphia", "state": "PA", "country": "US", "coord": {"lon": -79.5, "lat": 40.2}},
+		{"id": 4440076, "name": "Philadelphia", "state": "MS", "country": "US", "coord": {"lon": -89.1167, "lat": 32.7715}},
+		{"id": 2950159, "name": "Berlin", "country": "DE", "coord": {"lon": 13.41053, "lat": 52.524368}},
+		{"id": 5083330, "name": "Berlin", "state": "NH", "country": "US", "coord": {"lon": -71.185081, "lat": 44.468678}},
+		{"id": 2950096, "name": "Bernau bei Berlin", "country": "DE", "coord": {"lon": 13.58, "lat": 52.68}},
+		{"id": 2661552, "name": "Bern", "country": "CH", "coord": {"lon": 7.44744, "lat": 46.947479}}
+	]`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		query string
+		limit int
+		want  []int
+	}{
+		{"philadelph", 2, []int{4560349, 5205388}},
+		{"Philedelphia", 10, []int{4560349, 5205388, 4440076}},
+		{"berlin", 10, []int{2950159, 5083330}},
+		{"ber", 10, []int{2661552, 2950159, 5083330, 2950096}},
+		{"bern", 10, []int{2661552, 2950096, 2950159, 5083330}},
+		{"tokyo", 10, nil},
+		{"", 10, nil},
+	}
+	for _, tt := range tests {
+		var got []int
+		for _, m := range idx.Search(tt.query, tt.limit) {
+			got = append(got, m.ID)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%q: expected %v, got %v", tt.query, tt.want, got)
+		}
+	}
+
+	m := idx.Search("philedelphia", 1)
+	if len(m) != 1 || m[0].Distance != 1 || m[0].Coord.Latitude != 39.952339 {
+		t.Errorf("Expected Philadelphia one edit away, got %+v", m)
+	}
+}
+
+func TestPrefixDistance(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		q, name string
+		want    int
+	}{
+		{"phil", "philadelphia", 0},
+		{"phjl", "philadelphia", 1},
+		{"philadelphia", "phil", 8},
+		{"berlin", "berlin", 0},
+		{"brelin", "berlin", 2},
+	}
+	for _, tt := range tests {
+		if got := prefixDistance([]rune(tt.q), []rune(tt.name), 100); got != tt.want {
+			t.Errorf("%q, %q: expected %d, got %d", tt.q, tt.name, tt.want, got)
+		}
+	}
+}
+
+func TestLazy(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "citylist")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	name := filepath.Join(dir, "city.list.json")
+
+	l := NewLazy(name)
+	if err := ioutil.WriteFile(name, []byte(testList), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := l.Preload(context.Background()); err != nil {
+		t.Fatal(err)
+	}
+	os.Remove(name)
+	idx, err := l.Index(context.Background())
+	if err != nil || idx.Len() != 4 {
+		t.Errorf("Expected the loaded index, got %v and %v", idx, err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	missing := NewLazy(filepath.Join(dir, "missing.json"))
+	if _, err := missing.Index(ctx); err == nil {
+		t.Errorf("Expected an error, got %v", err)
+	}
+	if err := missing.Preload(context.Background()); !os.IsNotExist(err) {
+		t.Errorf("Expected a missing file error, got %v", err)
+	}
+}
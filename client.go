@@ -0,0 +1,269 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errGroupCount = errors.New("group needs 1 to 20 location IDs")
+
+// maxGroupCount is the largest number of location IDs /group accepts.
+const maxGroupCount = 20
+
+// Client gives access to the OWM API without storing results on the
+// receiver.  Every call returns a freshly allocated value, so a single
+// Client can safely be shared between goroutines.
+type Client struct {
+	unit     string
+	lang     string
+	key      string
+	geocache *geocodeCache
+	gridMu   sync.Mutex
+	grid     map[Coordinates]gridEntry
+	outageMu sync.Mutex
+	outage   time.Time
+	capMu    sync.Mutex
+	caps     *Capabilities
+	*Settings
+}
+
+// NewClient returns a new Client pointer with the supplied parameters
+func NewClient(unit, lang, key string, options ...Option) (*Client, error) {
+	unitChoice := strings.ToUpper(unit)
+	langChoice := strings.ToUpper(lang)
+
+	if !ValidDataUnit(unitChoice) {
+		return nil, errUnitUnavailable
+	}
+
+	if !ValidLangCode(langChoice) {
+		return nil, errLangUnavailable
+	}
+
+	k, err := setKey(key)
+	if err != nil {
+		return nil, err
+	}
+
+	c := &Client{
+		unit:     DataUnits[unitChoice],
+		lang:     langChoice,
+		key:      k,
+		geocache: &geocodeCache{entries: map[string]*GeoLocation{}},
+		grid:     map[Coordinates]gridEntry{},
+		Settings: NewSettings(),
+	}
+
+	if err := setOptions(c.Settings, options); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// Error implements the error interface.
+func (e *APIError) Error() string {
+	return fmt.Sprintf("openweathermap: %s (%s)", e.Message, e.COD)
+}
+
+// query returns params merged with the parameters sent with every
+// request made by the Client.
+func (c *Client) query(params url.Values) url.Values {
+	q := url.Values{}
+	for k, vs := range params {
+		q[k] = vs
+	}
+	q.Set("appid", c.key)
+	q.Set("units", c.unit)
+	q.Set("lang", c.lang)
+	return q
+}
+
+// coordinateParams returns the lat and lon query parameters for location.
+func coordinateParams(location *Coordinates) url.Values {
+	return url.Values{
+		"lat": {strconv.FormatFloat(location.Latitude, 'f', -1, 64)},
+		"lon": {strconv.FormatFloat(location.Longitude, 'f', -1, 64)},
+	}
+}
+
+// get sends a GET request for u and decodes the JSON response into v.
+// Responses with a non 2xx status are returned as an *APIError.
+func (c *Client) get(ctx context.Context, u string, v interface{}) error {
+	response, err := c.send(ctx, u)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	return c.decode(response, v)
+}
+
+// send sends a GET request for u.  Responses with a non 2xx status are
+// closed and returned as an *APIError; the caller closes the body of
+// any other response.
+func (c *Client) send(ctx context.Context, u string) (*http.Response, error) {
+	return c.do(ctx, http.MethodGet, u, nil)
+}
+
+// do sends a request with the given method and JSON body, which may be
+// nil, for u, handling the response like send.
+func (c *Client) do(ctx context.Context, method, u string, body io.Reader) (*http.Response, error) {
+	req, err := http.NewRequest(method, u, body)
+	if err != nil {
+		return nil, err
+	}
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	response, err := c.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	c.observe(response.StatusCode)
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		defer response.Body.Close()
+		apiErr := &APIError{COD: strconv.Itoa(response.StatusCode)}
+		var body struct {
+			Message string `json:"message"`
+		}
+		if err := json.NewDecoder(io.LimitReader(response.Body, c.maxResponseSize)).Decode(&body); err == nil && body.Message != "" {
+			apiErr.Message = body.Message
+		} else {
+			apiErr.Message = http.StatusText(response.StatusCode)
+		}
+		return nil, apiErr
+	}
+	return response, nil
+}
+
+// sendJSON sends a request with in, if not nil, encoded as JSON as its
+// body and decodes the response into out, if not nil.
+func (c *Client) sendJSON(ctx context.Context, method, u string, in, out interface{}) error {
+	var body io.Reader
+	if in != nil {
+		b, err := json.Marshal(in)
+		if err != nil {
+			return err
+		}
+		body = bytes.NewReader(b)
+	}
+
+	response, err := c.do(ctx, method, u, body)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if out == nil {
+		return nil
+	}
+	return c.decode(response, out)
+}
+
+// CurrentInto requests the current weather for the location described
+// by params (e.g. "q", "id", "lat" and "lon" or "zip") and decodes the
+// response into v, which can be any type encoding/json can decode into.
+// The API key, units and language are added by the Client.
+func (c *Client) CurrentInto(ctx context.Context, params url.Values, v interface{}) error {
+	return c.get(ctx, c.dataURL("weather")+"?"+c.query(params).Encode(), v)
+}
+
+// current requests the current weather for the given location
+// parameters.
+func (c *Client) current(ctx context.Context, params url.Values) (*CurrentWeatherData, error) {
+	w := &CurrentWeatherData{
+		Unit: c.unit,
+		Lang: c.lang,
+	}
+	if c.xml {
+		if err := c.currentXML(ctx, params, w); err != nil {
+			return nil, err
+		}
+		return w, nil
+	}
+	if err := c.CurrentInto(ctx, params, w); err != nil {
+		return nil, err
+	}
+	return w, nil
+}
+
+// CurrentByName returns the current weather for the provided location
+// name.  The returned value only holds data; its Key and Settings are
+// not set.
+func (c *Client) CurrentByName(ctx context.Context, location string) (*CurrentWeatherData, error) {
+	return c.current(ctx, url.Values{"q": {location}})
+}
+
+// CurrentByCoordinates returns the current weather for the provided
+// location coordinates.
+func (c *Client) CurrentByCoordinates(ctx context.Context, location *Coordinates) (*CurrentWeatherData, error) {
+	return c.current(ctx, coordinateParams(location))
+}
+
+// CurrentByID returns the current weather for the provided location ID.
+func (c *Client) CurrentByID(ctx context.Context, id int) (*CurrentWeatherData, error) {
+	return c.current(ctx, url.Values{"id": {strconv.Itoa(id)}})
+}
+
+// CurrentByIDs returns the current weather for 1 to 20 location IDs
+// with a single request, in the order OWM returns them.
+func (c *Client) CurrentByIDs(ctx context.Context, ids []int) ([]*CurrentWeatherData, error) {
+	if len(ids) < 1 || len(ids) > maxGroupCount {
+		return nil, errGroupCount
+	}
+	s := make([]string, len(ids))
+	for i, id := range ids {
+		s[i] = strconv.Itoa(id)
+	}
+	params := url.Values{"id": {strings.Join(s, ",")}}
+
+	var group struct {
+		List []*CurrentWeatherData `json:"list"`
+	}
+	if err := c.get(ctx, c.dataURL("group")+"?"+c.query(params).Encode(), &group); err != nil {
+		return nil, err
+	}
+	for _, w := range group.List {
+		w.Unit = c.unit
+		w.Lang = c.lang
+	}
+	return group.List, nil
+}
+
+// CurrentByZip returns the current weather for the provided zip code.
+// The zip code is a string so leading zeros and non-numeric postal
+// codes are preserved.  Both are normalized and checked as by
+// NewPostalCode.
+func (c *Client) CurrentByZip(ctx context.Context, zip, countryCode string) (*CurrentWeatherData, error) {
+	params, err := zipParams(zip, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	return c.current(ctx, params)
+}
@@ -0,0 +1,328 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"os"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testKey = "0123456789abcdef0123456789abcdef"
+
+// handlerTransport answers requests with an http.Handler instead of
+// sending them over the network.
+type handlerTransport struct {
+	handler http.Handler
+}
+
+func (t *handlerTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	rec := httptest.NewRecorder()
+	t.handler.ServeHTTP(rec, r)
+	res := rec.Result()
+	res.Request = r
+	return res, nil
+}
+
+// newTestClient returns a Client whose requests are served by h.
+func newTestClient(t *testing.T, h http.HandlerFunc, options ...Option) *Client {
+	hc := &http.Client{Transport: &handlerTransport{handler: h}}
+	c, err := NewClient("c", "en", testKey, append([]Option{WithHttpClient(hc)}, options...)...)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c
+}
+
+func TestNewClient(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewClient("f", "en", os.Getenv("OWM_API_KEY"))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if reflect.TypeOf(c).String() != "*openweathermap.Client" {
+		t.Error("incorrect data type returned")
+	}
+
+	if _, err := NewClient("x", "en", os.Getenv("OWM_API_KEY")); err != errUnitUnavailable {
+		t.Errorf("Expected %v, but got %v", errUnitUnavailable, err)
+	}
+
+	if _, err := NewClient("f", "blah", os.Getenv("OWM_API_KEY")); err != errLangUnavailable {
+		t.Errorf("Expected %v, but got %v", errLangUnavailable, err)
+	}
+
+	if _, err := NewClient("f", "en", os.Getenv("OWM_API_KEY"), nil); err != errInvalidOption {
+		t.Errorf("Expected %v, but got %v", errInvalidOption, err)
+	}
+}
+
+func TestClientCurrent(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	var mu sync.Mutex
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		queries = append(queries, r.URL.RawQuery)
+		mu.Unlock()
+		fmt.Fprint(w, `{"id":4560349,"name":"Philadelphia","main":{"temp":35.6,"feels_like":39.1},"visibility":8000,"wind":{"speed":6.2,"deg":310,"gust":11.3},"rain":{"1h":0.4,"3h":1.2}}`)
+	})
+
+	ctx := context.Background()
+	calls := []func() (*CurrentWeatherData, error){
+		func() (*CurrentWeatherData, error) { return c.CurrentByName(ctx, "Philadelphia") },
+		func() (*CurrentWeatherData, error) {
+			return c.CurrentByCoordinates(ctx, &Coordinates{Longitude: -75.16, Latitude: 39.95})
+		},
+		func() (*CurrentWeatherData, error) { return c.CurrentByID(ctx, 4560349) },
+		func() (*CurrentWeatherData, error) { return c.CurrentByZip(ctx, "19125", "US") },
+	}
+
+	for _, call := range calls {
+		w, err := call()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.ID != 4560349 || w.Name != "Philadelphia" || w.Main.Temp != 35.6 || w.Main.FeelsLike != 39.1 || w.VisibilityDistance() != 8 || w.Wind.Gust != 11.3 || w.Rain.OneH != 0.4 || w.Rain.ThreeH != 1.2 {
+			t.Errorf("unexpected result %+v", w)
+		}
+		if w.Unit != "metric" || w.Lang != "EN" {
+			t.Errorf("Expected unit metric and lang EN, got %s and %s", w.Unit, w.Lang)
+		}
+	}
+
+	want := []string{
+		"appid=" + testKey + "&lang=EN&q=Philadelphia&units=metric",
+		"appid=" + testKey + "&lang=EN&lat=39.95&lon=-75.16&units=metric",
+		"appid=" + testKey + "&id=4560349&lang=EN&units=metric",
+		"appid=" + testKey + "&lang=EN&units=metric&zip=19125%2CUS",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("Expected queries %v, got %v", want, queries)
+	}
+}
+
+func TestClientCurrentByIDs(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/group" || r.URL.Query().Get("id") != "524901,703448" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		fmt.Fprint(w, `{"cnt":2,"list":[{"id":524901,"name":"Moscow","main":{"temp":7.2}},{"id":703448,"name":"Kyiv","main":{"temp":9.1}}]}`)
+	})
+
+	list, err := c.CurrentByIDs(context.Background(), []int{524901, 703448})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 2 || list[0].Name != "Moscow" || list[1].Main.Temp != 9.1 || list[1].Unit != "metric" {
+		t.Errorf("unexpected result %+v", list)
+	}
+
+	if _, err := c.CurrentByIDs(context.Background(), nil); err != errGroupCount {
+		t.Errorf("Expected %v, got %v", errGroupCount, err)
+	}
+	if _, err := c.CurrentByIDs(context.Background(), make([]int, 21)); err != errGroupCount {
+		t.Errorf("Expected %v, got %v", errGroupCount, err)
+	}
+}
+
+func TestClientCurrentConcurrent(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintf(w, `{"name":%q}`, r.URL.Query().Get("q"))
+	})
+
+	var wg sync.WaitGroup
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(name string) {
+			defer wg.Done()
+			w, err := c.CurrentByName(context.Background(), name)
+			if err != nil {
+				t.Error(err)
+				return
+			}
+			if w.Name != name {
+				t.Errorf("Expected %s, got %s", name, w.Name)
+			}
+		}(fmt.Sprintf("city-%d", i))
+	}
+	wg.Wait()
+}
+
+func TestClientAPIError(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		fmt.Fprint(w, `{"cod":"404","message":"city not found"}`)
+	})
+
+	_, err := c.CurrentByName(context.Background(), "nowhere_")
+	apiErr, ok := err.(*APIError)
+	if !ok {
+		t.Fatalf("Expected *APIError, got %v", err)
+	}
+	if apiErr.COD != "404" || apiErr.Message != "city not found" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+}
+
+func TestClientContext(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Context().Err() == nil {
+			t.Error("Expected the caller's canceled context")
+		}
+		fmt.Fprint(w, `{}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	c.CurrentByID(ctx, 1)
+}
+
+func TestClientStrictDecoding(t *testing.T) {
+	t.Parallel()
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"Dublin","brand_new_field":1}`)
+	}
+
+	if _, err := newTestClient(t, h).CurrentByName(context.Background(), "Dublin"); err != nil {
+		t.Errorf("Expected unknown fields to be ignored, got %v", err)
+	}
+
+	_, err := newTestClient(t, h, WithStrictDecoding()).CurrentByName(context.Background(), "Dublin")
+	decErr, ok := err.(*DecodeError)
+	if !ok {
+		t.Fatalf("Expected *DecodeError, got %v", err)
+	}
+	if !strings.Contains(decErr.Err.Error(), "brand_new_field") {
+		t.Errorf("Expected the unknown field to be named, got %v", decErr.Err)
+	}
+	if !strings.Contains(decErr.Excerpt, `"name":"Dublin"`) {
+		t.Errorf("Expected a body excerpt, got %q", decErr.Excerpt)
+	}
+}
+
+func TestClientRawResponse(t *testing.T) {
+	t.Parallel()
+
+	const body = `{"name":"Dublin","visibility":10000}`
+	var raw []byte
+	var rawURL *url.URL
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, body)
+	}, WithRawResponse(func(u *url.URL, b []byte) {
+		rawURL = u
+		raw = b
+	}))
+
+	w, err := c.CurrentByName(context.Background(), "Dublin")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.Name != "Dublin" {
+		t.Errorf("Expected Dublin, got %s", w.Name)
+	}
+	if string(raw) != body {
+		t.Errorf("Expected raw body %s, got %s", body, raw)
+	}
+	if rawURL.Query().Get("q") != "Dublin" || rawURL.Query().Get("appid") != "" {
+		t.Errorf("Expected the request URL without key, got %s", rawURL)
+	}
+
+	if _, err := NewClient("c", "en", testKey, WithRawResponse(nil)); err != errInvalidOption {
+		t.Errorf("Expected %v, but got %v", errInvalidOption, err)
+	}
+}
+
+func TestClientCurrentInto(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("id") != "2172797" || r.URL.Query().Get("appid") != testKey {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"name":"Cairns","main":{"temp":21.5,"humidity":80},"wind":{"speed":3}}`)
+	})
+
+	var v struct {
+		Name string `json:"name"`
+		Main struct {
+			Temp float64 `json:"temp"`
+		} `json:"main"`
+	}
+	if err := c.CurrentInto(context.Background(), url.Values{"id": {"2172797"}}, &v); err != nil {
+		t.Fatal(err)
+	}
+	if v.Name != "Cairns" || v.Main.Temp != 21.5 {
+		t.Errorf("unexpected result %+v", v)
+	}
+}
+
+func TestClientMaxResponseSize(t *testing.T) {
+	t.Parallel()
+
+	h := func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"name":"Llanfairpwllgwyngyllgogerychwyrndrobwllllantysiliogogogoch"}`)
+	}
+
+	if _, err := newTestClient(t, h).CurrentByName(context.Background(), "Llanfair"); err != nil {
+		t.Errorf("Expected the default limit to allow the response, got %v", err)
+	}
+
+	_, err := newTestClient(t, h, WithMaxResponseSize(32)).CurrentByName(context.Background(), "Llanfair")
+	if !errors.Is(err, ErrResponseTooLarge) {
+		t.Errorf("Expected %v, got %v", ErrResponseTooLarge, err)
+	}
+}
+
+func TestClientTimeout(t *testing.T) {
+	t.Parallel()
+
+	hc := &http.Client{}
+	c, err := NewClient("c", "en", testKey, WithTimeout(time.Second), WithHttpClient(hc))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if c.client.Timeout != time.Second {
+		t.Errorf("Expected timeout %v, got %v", time.Second, c.client.Timeout)
+	}
+	if hc.Timeout != 0 {
+		t.Error("WithTimeout modified the caller's http client")
+	}
+
+	if _, err := NewClient("c", "en", testKey, WithTimeout(0)); err != errInvalidOption {
+		t.Errorf("Expected %v, but got %v", errInvalidOption, err)
+	}
+}
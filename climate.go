@@ -0,0 +1,91 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// maxClimateCount is the number of days covered by the climate forecast.
+const maxClimateCount = 30
+
+// ClimateForecastDay holds the climate forecast for one day.
+type ClimateForecastDay struct {
+	Dt        Timestamp   `json:"dt"`
+	Sunrise   Timestamp   `json:"sunrise"`
+	Sunset    Timestamp   `json:"sunset"`
+	Temp      Temperature `json:"temp"`
+	FeelsLike Temperature `json:"feels_like"`
+	Pressure  float64     `json:"pressure"`
+	Humidity  int         `json:"humidity"`
+	Weather   []Weather   `json:"weather"`
+	Speed     float64     `json:"speed"`
+	Deg       float64     `json:"deg"`
+	Clouds    int         `json:"clouds"`
+	Rain      float64     `json:"rain"`
+	Snow      float64     `json:"snow"`
+}
+
+// ClimateForecastData holds the 30 day climate forecast for a location.
+type ClimateForecastData struct {
+	COD     string               `json:"cod"`
+	Message float64              `json:"message"`
+	City    City                 `json:"city"`
+	Cnt     int                  `json:"cnt"`
+	List    []ClimateForecastDay `json:"list"`
+	Unit    string
+	Lang    string
+}
+
+// climateForecast requests the climate forecast for cnt days, at most
+// 30, for the given location parameters.
+func (c *Client) climateForecast(ctx context.Context, params url.Values, cnt int) (*ClimateForecastData, error) {
+	if c.xml {
+		return nil, errXMLUnsupported
+	}
+	if cnt < 1 || cnt > maxClimateCount {
+		return nil, errForecastCount
+	}
+	params.Set("cnt", strconv.Itoa(cnt))
+
+	f := &ClimateForecastData{
+		Unit: c.unit,
+		Lang: c.lang,
+	}
+	if err := c.get(ctx, c.dataURL("forecast/climate")+"?"+c.query(params).Encode(), f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ClimateForecastByName returns the climate forecast for the next cnt
+// days, 1 to 30, for the provided location name.  The climate forecast
+// requires a subscription to it.
+func (c *Client) ClimateForecastByName(ctx context.Context, location string, cnt int) (*ClimateForecastData, error) {
+	return c.climateForecast(ctx, url.Values{"q": {location}}, cnt)
+}
+
+// ClimateForecastByCoordinates returns the climate forecast for the next
+// cnt days, 1 to 30, for the provided location coordinates.
+func (c *Client) ClimateForecastByCoordinates(ctx context.Context, location *Coordinates, cnt int) (*ClimateForecastData, error) {
+	return c.climateForecast(ctx, coordinateParams(location), cnt)
+}
+
+// ClimateForecastByID returns the climate forecast for the next cnt days,
+// 1 to 30, for the provided location ID.
+func (c *Client) ClimateForecastByID(ctx context.Context, id, cnt int) (*ClimateForecastData, error) {
+	return c.climateForecast(ctx, url.Values{"id": {strconv.Itoa(id)}}, cnt)
+}
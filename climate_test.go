@@ -0,0 +1,58 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClientClimateForecast(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "pro.openweathermap.org" || r.URL.Path != "/data/2.5/forecast/climate" {
+			t.Errorf("unexpected url %s", r.URL)
+		}
+		if q := r.URL.Query(); q.Get("cnt") != "2" || q.Get("lat") != "35" || q.Get("lon") != "139" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"cod":"200","message":0.3,"cnt":2,"city":{"id":1851632,"name":"Shuzenji","coord":{"lon":139,"lat":35},"country":"JP"},"list":[
+{"dt":1594382400,"sunrise":1594324970,"sunset":1594377216,"temp":{"day":23.5,"min":19.1,"max":24.9,"night":19.1,"eve":22.1,"morn":20.4},
+ "feels_like":{"day":25.2,"night":20.3,"eve":24.1,"morn":21.9},"pressure":1009,"humidity":90,
+ "weather":[{"id":501,"main":"Rain","description":"moderate rain","icon":"10d"}],"speed":1.6,"deg":210,"clouds":100,"rain":12.1},
+{"dt":1594468800,"sunrise":1594411406,"sunset":1594463597,"temp":{"day":25.3,"min":20.2,"max":26.8,"night":21.2,"eve":24.1,"morn":20.2},
+ "feels_like":{"day":27.1,"night":22.6,"eve":26.2,"morn":21.5},"pressure":1011,"humidity":84,
+ "weather":[{"id":500,"main":"Rain","description":"light rain","icon":"10d"}],"speed":2.1,"deg":196,"clouds":93,"rain":1.3}]}`)
+	}, WithStrictDecoding())
+
+	f, err := c.ClimateForecastByCoordinates(context.Background(), &Coordinates{Latitude: 35, Longitude: 139}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.City.Name != "Shuzenji" || len(f.List) != 2 || f.Cnt != 2 {
+		t.Errorf("unexpected result %+v", f)
+	}
+	if d := f.List[0]; d.Temp.Min != 19.1 || d.Temp.Max != 24.9 || d.Rain != 12.1 || d.FeelsLike.Day != 25.2 {
+		t.Errorf("unexpected day %+v", d)
+	}
+
+	for _, cnt := range []int{0, 31} {
+		if _, err := c.ClimateForecastByID(context.Background(), 1851632, cnt); err != errForecastCount {
+			t.Errorf("Expected %v for cnt %d, got %v", errForecastCount, cnt, err)
+		}
+	}
+}
@@ -0,0 +1,268 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command owm holds tools for working with the openweathermap package.
+// Its debug replay command re-decodes a recorded OWM API response
+// through the structs of the package and prints what was parsed along
+// with every field of the response that was ignored.  Attach its output
+// when reporting a decoding bug.
+//
+// Usage:
+//
+//	owm debug replay [-t type] file  decode the response in file into type (default current)
+//
+// The types are current, forecast5, forecast16, hourly, climate,
+// history, uv, pollution, airpollution, onecall, timemachine,
+// daysummary and overview.
+//
+// Example:
+//
+//	curl -o weather.json "https://api.openweathermap.org/data/2.5/weather?q=Dublin&appid=$OWM_API_KEY"
+//	owm debug replay weather.json
+//	owm debug replay -t forecast5 forecast.json
+package main
+
+import (
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strings"
+
+	owm "github.com/briandowns/openweathermap"
+)
+
+const replayUsage = "usage: owm debug replay [-t type] file"
+
+// targets maps the -t flag values to the type the response is decoded
+// into.
+var targets = map[string]func() interface{}{
+	"current":      func() interface{} { return &owm.CurrentWeatherData{} },
+	"forecast5":    func() interface{} { return &owm.Forecast5WeatherData{} },
+	"forecast16":   func() interface{} { return &owm.Forecast16WeatherData{} },
+	"hourly":       func() interface{} { return &owm.Forecast5WeatherData{} },
+	"climate":      func() interface{} { return &owm.ClimateForecastData{} },
+	"history":      func() interface{} { return &owm.HistoricalWeatherData{} },
+	"uv":           func() interface{} { return &owm.UV{} },
+	"pollution":    func() interface{} { return &owm.Pollution{} },
+	"airpollution": func() interface{} { return &owm.AirPollution{} },
+	"onecall":      func() interface{} { return &owm.OneCallData{} },
+	"timemachine":  func() interface{} { return &owm.OneCallTimemachineData{} },
+	"daysummary":   func() interface{} { return &owm.DaySummaryData{} },
+	"overview":     func() interface{} { return &owm.OverviewData{} },
+}
+
+var (
+	unmarshalerType = reflect.TypeOf((*json.Unmarshaler)(nil)).Elem()
+	marshalerType   = reflect.TypeOf((*json.Marshaler)(nil)).Elem()
+)
+
+// ignored walks the decoded JSON value raw alongside the type t and
+// appends the path of every object key no struct field maps to.
+func ignored(path string, raw interface{}, t reflect.Type, out *[]string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if reflect.PtrTo(t).Implements(unmarshalerType) {
+		roundTrip(path, raw, t, out)
+		return
+	}
+
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		if t.Kind() != reflect.Struct {
+			return
+		}
+		for key, val := range v {
+			f, ok := field(t, key)
+			if !ok {
+				*out = append(*out, path+"."+key)
+				continue
+			}
+			ignored(path+"."+key, val, f.Type, out)
+		}
+	case []interface{}:
+		if t.Kind() != reflect.Slice && t.Kind() != reflect.Array {
+			return
+		}
+		for i, val := range v {
+			ignored(fmt.Sprintf("%s[%d]", path, i), val, t.Elem(), out)
+		}
+	}
+}
+
+// roundTrip appends the paths of the keys of raw that are lost when it's
+// decoded into t, a type with its own JSON decoding such as owm.Alert,
+// and encoded again.  Those types encode themselves the way the API
+// does, so the keys their decoding reads survive.  Types without their
+// own encoding, and values that aren't objects or arrays, such as the
+// numbers of owm.Timestamp, are not checked.
+func roundTrip(path string, raw interface{}, t reflect.Type, out *[]string) {
+	switch raw.(type) {
+	case map[string]interface{}, []interface{}:
+	default:
+		return
+	}
+	if !t.Implements(marshalerType) && !reflect.PtrTo(t).Implements(marshalerType) {
+		return
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return
+	}
+	v := reflect.New(t)
+	if err := json.Unmarshal(b, v.Interface()); err != nil {
+		return
+	}
+	if b, err = json.Marshal(v.Interface()); err != nil {
+		return
+	}
+	var back interface{}
+	if err := json.Unmarshal(b, &back); err != nil {
+		return
+	}
+	lost(path, raw, back, out)
+}
+
+// lost appends the paths of the object keys of raw missing from back.
+func lost(path string, raw, back interface{}, out *[]string) {
+	switch v := raw.(type) {
+	case map[string]interface{}:
+		b, _ := back.(map[string]interface{})
+		for key, val := range v {
+			bv, ok := b[key]
+			if !ok {
+				*out = append(*out, path+"."+key)
+				continue
+			}
+			lost(path+"."+key, val, bv, out)
+		}
+	case []interface{}:
+		b, _ := back.([]interface{})
+		for i, val := range v {
+			if i < len(b) {
+				lost(fmt.Sprintf("%s[%d]", path, i), val, b[i], out)
+			}
+		}
+	}
+}
+
+// field finds the struct field encoding/json would decode key into.
+func field(t reflect.Type, key string) (reflect.StructField, bool) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.PkgPath != "" && !f.Anonymous {
+			continue
+		}
+		name := strings.Split(f.Tag.Get("json"), ",")[0]
+		if name == "-" {
+			continue
+		}
+		if f.Anonymous && name == "" {
+			ft := f.Type
+			if ft.Kind() == reflect.Ptr {
+				ft = ft.Elem()
+			}
+			if ft.Kind() == reflect.Struct {
+				if sf, ok := field(ft, key); ok {
+					return sf, true
+				}
+			}
+			continue
+		}
+		if name == "" {
+			name = f.Name
+		}
+		if strings.EqualFold(name, key) {
+			return f, true
+		}
+	}
+	return reflect.StructField{}, false
+}
+
+// replay decodes the recorded response in the file named by args into
+// the type selected by its -t flag and writes what was parsed and
+// ignored to stdout.
+func replay(args []string, stdout io.Writer) (int, error) {
+	fs := flag.NewFlagSet("replay", flag.ContinueOnError)
+	fs.SetOutput(ioutil.Discard)
+	typ := fs.String("t", "current", "type to decode into")
+	if err := fs.Parse(args); err != nil || fs.NArg() != 1 {
+		return 2, errors.New(replayUsage)
+	}
+	target, ok := targets[*typ]
+	if !ok {
+		return 2, fmt.Errorf("unknown type %q; %s", *typ, replayUsage)
+	}
+
+	data, err := ioutil.ReadFile(fs.Arg(0))
+	if err != nil {
+		return 1, err
+	}
+
+	v := target()
+	if err := json.Unmarshal(data, v); err != nil {
+		return 1, fmt.Errorf("decoding into %T failed: %v", v, err)
+	}
+	var raw interface{}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return 1, err
+	}
+
+	parsed, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return 1, err
+	}
+	fmt.Fprintf(stdout, "Parsed into %T:\n%s\n\n", v, parsed)
+
+	var skipped []string
+	ignored("", raw, reflect.TypeOf(v), &skipped)
+	sort.Strings(skipped)
+
+	if len(skipped) == 0 {
+		fmt.Fprintln(stdout, "No fields were ignored.")
+		return 0, nil
+	}
+	fmt.Fprintln(stdout, "Ignored fields:")
+	for _, s := range skipped {
+		fmt.Fprintf(stdout, "    %s\n", strings.TrimPrefix(s, "."))
+	}
+	return 0, nil
+}
+
+func run(args []string, stdout io.Writer) (int, error) {
+	if len(args) < 2 || args[0] != "debug" {
+		return 2, errors.New(replayUsage)
+	}
+
+	switch args[1] {
+	case "replay":
+		return replay(args[2:], stdout)
+	}
+	return 2, fmt.Errorf("unknown command %q", "debug "+args[1])
+}
+
+func main() {
+	status, err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	os.Exit(status)
+}
@@ -0,0 +1,98 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func writeResponse(t *testing.T, body string) string {
+	dir, err := ioutil.TempDir("", "owm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "response.json"), []byte(body), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestReplay(t *testing.T) {
+	dir := writeResponse(t, `{"name":"Dublin","main":{"temp":12.5,"pm":3},"wind":{"speed":4.1},"weather":[{"id":500,"extra":true}],"novel":1}`)
+	defer os.RemoveAll(dir)
+
+	var out bytes.Buffer
+	status, err := run([]string{"debug", "replay", filepath.Join(dir, "response.json")}, &out)
+	if err != nil || status != 0 {
+		t.Fatalf("Expected status 0, got %d and %v", status, err)
+	}
+	got := out.String()
+	if !strings.HasPrefix(got, "Parsed into *openweathermap.CurrentWeatherData:") || !strings.Contains(got, `"name": "Dublin"`) {
+		t.Errorf("unexpected parsed output %q", got)
+	}
+	if want := "Ignored fields:\n    main.pm\n    novel\n    weather[0].extra\n"; !strings.HasSuffix(got, want) {
+		t.Errorf("Expected output ending in %q, got %q", want, got)
+	}
+}
+
+func TestReplayUsage(t *testing.T) {
+	dir := writeResponse(t, `{}`)
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "response.json")
+
+	for _, args := range [][]string{
+		nil,
+		{"debug"},
+		{"debug", "record", path},
+		{"debug", "replay"},
+		{"debug", "replay", "-t", "radar", path},
+	} {
+		if status, err := run(args, ioutil.Discard); status != 2 || err == nil {
+			t.Errorf("%q: expected status 2 and an error, got %d and %v", args, status, err)
+		}
+	}
+
+	for typ := range targets {
+		if status, err := run([]string{"debug", "replay", "-t", typ, path}, ioutil.Discard); status != 0 || err != nil {
+			t.Errorf("%s: expected status 0, got %d and %v", typ, status, err)
+		}
+	}
+	if status, err := run([]string{"debug", "replay", filepath.Join(dir, "missing.json")}, ioutil.Discard); status != 1 || err == nil {
+		t.Errorf("Expected status 1 and an error, got %d and %v", status, err)
+	}
+}
+
+func TestReplayOneCall(t *testing.T) {
+	dir := writeResponse(t, `{"lat":53.3,"minutely":[{"dt":1618317040,"precipitation":0,"intensity":1}],"alerts":[{"sender_name":"Met Éireann","event":"Wind","start":1618317040,"end":1618360240,"description":"Gales","tags":["Wind"],"extra":{"a":1}}]}`)
+	defer os.RemoveAll(dir)
+
+	var out bytes.Buffer
+	status, err := run([]string{"debug", "replay", "-t", "onecall", filepath.Join(dir, "response.json")}, &out)
+	if err != nil || status != 0 {
+		t.Fatalf("Expected status 0, got %d and %v", status, err)
+	}
+	got := out.String()
+	if !strings.HasPrefix(got, "Parsed into *openweathermap.OneCallData:") {
+		t.Errorf("unexpected parsed output %q", got)
+	}
+	if want := "Ignored fields:\n    alerts[0].extra\n    minutely[0].intensity\n"; !strings.HasSuffix(got, want) {
+		t.Errorf("Expected output ending in %q, got %q", want, got)
+	}
+}
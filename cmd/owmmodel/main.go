@@ -0,0 +1,270 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Command owmmodel emits a machine-readable description of the exported
+// data model of a Go package and compares two such descriptions, so
+// breaking struct changes between versions of the openweathermap
+// package can be detected programmatically.
+//
+// Usage:
+//
+//	owmmodel describe [dir]          write the model of the package in dir (default ".") as JSON
+//	owmmodel diff old.json new.json  list changes, exiting with status 1 on breaking ones
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/printer"
+	"go/token"
+	"io"
+	"io/ioutil"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Model describes the exported struct types of a package.
+type Model struct {
+	Package string `json:"package"`
+	Types   []Type `json:"types"`
+}
+
+// Type is an exported struct type.
+type Type struct {
+	Name   string  `json:"name"`
+	Fields []Field `json:"fields"`
+}
+
+// Field is an exported or embedded field of a struct type.
+type Field struct {
+	Name     string `json:"name"`
+	Type     string `json:"type"`
+	JSON     string `json:"json,omitempty"`
+	Embedded bool   `json:"embedded,omitempty"`
+}
+
+// Change is a difference between two models.
+type Change struct {
+	Breaking    bool
+	Description string
+}
+
+// String implements fmt.Stringer.
+func (c Change) String() string {
+	if c.Breaking {
+		return "breaking: " + c.Description
+	}
+	return "added: " + c.Description
+}
+
+// describe parses the non-test Go files in dir and returns the model of
+// the package found there.
+func describe(dir string) (*Model, error) {
+	fset := token.NewFileSet()
+	pkgs, err := parser.ParseDir(fset, dir, func(fi os.FileInfo) bool {
+		return !strings.HasSuffix(fi.Name(), "_test.go")
+	}, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	// Packages and files are visited in name order, so types declared
+	// more than once, e.g. in files for different build tags, are
+	// always described in the same order.
+	var names []string
+	for name := range pkgs {
+		if !strings.HasSuffix(name, "_test") {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	m := &Model{Types: []Type{}}
+	for _, name := range names {
+		pkg := pkgs[name]
+		m.Package = name
+		var files []string
+		for filename := range pkg.Files {
+			files = append(files, filename)
+		}
+		sort.Strings(files)
+		for _, filename := range files {
+			f := pkg.Files[filename]
+			for _, decl := range f.Decls {
+				gd, ok := decl.(*ast.GenDecl)
+				if !ok || gd.Tok != token.TYPE {
+					continue
+				}
+				for _, spec := range gd.Specs {
+					ts := spec.(*ast.TypeSpec)
+					st, ok := ts.Type.(*ast.StructType)
+					if !ok || !ts.Name.IsExported() {
+						continue
+					}
+					m.Types = append(m.Types, Type{Name: ts.Name.Name, Fields: fields(fset, st)})
+				}
+			}
+		}
+	}
+	sort.SliceStable(m.Types, func(i, j int) bool { return m.Types[i].Name < m.Types[j].Name })
+	return m, nil
+}
+
+// fields returns the exported and embedded fields of st in declaration
+// order.
+func fields(fset *token.FileSet, st *ast.StructType) []Field {
+	fs := []Field{}
+	for _, f := range st.Fields.List {
+		var buf bytes.Buffer
+		printer.Fprint(&buf, fset, f.Type)
+		typ := buf.String()
+
+		var tag string
+		if f.Tag != nil {
+			raw, _ := strconv.Unquote(f.Tag.Value)
+			tag = strings.Split(reflect.StructTag(raw).Get("json"), ",")[0]
+		}
+
+		if len(f.Names) == 0 {
+			fs = append(fs, Field{Name: strings.TrimPrefix(typ, "*"), Type: typ, JSON: tag, Embedded: true})
+			continue
+		}
+		for _, n := range f.Names {
+			if n.IsExported() {
+				fs = append(fs, Field{Name: n.Name, Type: typ, JSON: tag})
+			}
+		}
+	}
+	return fs
+}
+
+// diff lists the changes needed to get from old to new.
+func diff(old, new *Model) []Change {
+	var changes []Change
+
+	newTypes := map[string]Type{}
+	for _, t := range new.Types {
+		newTypes[t.Name] = t
+	}
+	oldTypes := map[string]Type{}
+	for _, t := range old.Types {
+		oldTypes[t.Name] = t
+	}
+
+	for _, ot := range old.Types {
+		nt, ok := newTypes[ot.Name]
+		if !ok {
+			changes = append(changes, Change{true, fmt.Sprintf("type %s removed", ot.Name)})
+			continue
+		}
+
+		newFields := map[string]Field{}
+		for _, f := range nt.Fields {
+			newFields[f.Name] = f
+		}
+		oldFields := map[string]Field{}
+		for _, of := range ot.Fields {
+			oldFields[of.Name] = of
+			nf, ok := newFields[of.Name]
+			switch {
+			case !ok:
+				changes = append(changes, Change{true, fmt.Sprintf("field %s.%s removed", ot.Name, of.Name)})
+			case nf.Type != of.Type:
+				changes = append(changes, Change{true, fmt.Sprintf("field %s.%s type changed from %s to %s", ot.Name, of.Name, of.Type, nf.Type)})
+			case nf.JSON != of.JSON:
+				changes = append(changes, Change{true, fmt.Sprintf("field %s.%s json name changed from %q to %q", ot.Name, of.Name, of.JSON, nf.JSON)})
+			}
+		}
+		for _, nf := range nt.Fields {
+			if _, ok := oldFields[nf.Name]; !ok {
+				changes = append(changes, Change{false, fmt.Sprintf("field %s.%s %s", nt.Name, nf.Name, nf.Type)})
+			}
+		}
+	}
+
+	for _, nt := range new.Types {
+		if _, ok := oldTypes[nt.Name]; !ok {
+			changes = append(changes, Change{false, fmt.Sprintf("type %s", nt.Name)})
+		}
+	}
+	return changes
+}
+
+func readModel(path string) (*Model, error) {
+	b, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	m := &Model{}
+	if err := json.Unmarshal(b, m); err != nil {
+		return nil, fmt.Errorf("%s: %v", path, err)
+	}
+	return m, nil
+}
+
+func run(args []string, stdout io.Writer) (int, error) {
+	if len(args) == 0 {
+		return 2, fmt.Errorf("usage: owmmodel describe [dir] | owmmodel diff old.json new.json")
+	}
+
+	switch args[0] {
+	case "describe":
+		dir := "."
+		if len(args) > 1 {
+			dir = args[1]
+		}
+		m, err := describe(dir)
+		if err != nil {
+			return 1, err
+		}
+		enc := json.NewEncoder(stdout)
+		enc.SetIndent("", "  ")
+		return 0, enc.Encode(m)
+	case "diff":
+		if len(args) != 3 {
+			return 2, fmt.Errorf("usage: owmmodel diff old.json new.json")
+		}
+		old, err := readModel(args[1])
+		if err != nil {
+			return 1, err
+		}
+		new, err := readModel(args[2])
+		if err != nil {
+			return 1, err
+		}
+		status := 0
+		for _, c := range diff(old, new) {
+			if c.Breaking {
+				status = 1
+			}
+			fmt.Fprintln(stdout, c)
+		}
+		return status, nil
+	}
+	return 2, fmt.Errorf("unknown command %q", args[0])
+}
+
+func main() {
+	status, err := run(os.Args[1:], os.Stdout)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+	}
+	os.Exit(status)
+}
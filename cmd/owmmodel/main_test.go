@@ -0,0 +1,167 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package main
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+)
+
+const oldSource = `package owm
+
+type Wind struct {
+	Speed float64 ` + "`json:\"speed\"`" + `
+	Deg   float64 ` + "`json:\"deg\"`" + `
+	note  string
+}
+
+type Main struct {
+	Temp float64 ` + "`json:\"temp\"`" + `
+}
+
+type unexported struct{}
+`
+
+const newSource = `package owm
+
+type Wind struct {
+	Speed float64 ` + "`json:\"speed\"`" + `
+	Deg   int     ` + "`json:\"deg\"`" + `
+	Gust  float64 ` + "`json:\"gust\"`" + `
+}
+
+type Clouds struct {
+	*Wind
+}
+`
+
+func writePackage(t *testing.T, src string) string {
+	dir, err := ioutil.TempDir("", "owmmodel")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := ioutil.WriteFile(filepath.Join(dir, "owm.go"), []byte(src), 0644); err != nil {
+		t.Fatal(err)
+	}
+	return dir
+}
+
+func TestDescribe(t *testing.T) {
+	dir := writePackage(t, oldSource)
+	defer os.RemoveAll(dir)
+
+	m, err := describe(dir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &Model{
+		Package: "owm",
+		Types: []Type{
+			{Name: "Main", Fields: []Field{{Name: "Temp", Type: "float64", JSON: "temp"}}},
+			{Name: "Wind", Fields: []Field{
+				{Name: "Speed", Type: "float64", JSON: "speed"},
+				{Name: "Deg", Type: "float64", JSON: "deg"},
+			}},
+		},
+	}
+	if !reflect.DeepEqual(m, want) {
+		t.Errorf("Expected %+v, got %+v", want, m)
+	}
+}
+
+func TestDescribeDeterministic(t *testing.T) {
+	dir := writePackage(t, oldSource)
+	defer os.RemoveAll(dir)
+	for _, f := range []struct{ name, src string }{
+		{"stats_linux.go", "package owm\n\ntype Stats struct {\n\tLoad float64\n}\n"},
+		{"stats_other.go", "package owm\n\ntype Stats struct {\n\tLoad int\n}\n"},
+	} {
+		if err := ioutil.WriteFile(filepath.Join(dir, f.name), []byte(f.src), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	var first bytes.Buffer
+	if _, err := run([]string{"describe", dir}, &first); err != nil {
+		t.Fatal(err)
+	}
+	for i := 0; i < 20; i++ {
+		var out bytes.Buffer
+		if _, err := run([]string{"describe", dir}, &out); err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(out.Bytes(), first.Bytes()) {
+			t.Fatalf("Expected identical output, got\n%s\nand\n%s", first.String(), out.String())
+		}
+	}
+}
+
+func TestDiff(t *testing.T) {
+	oldDir := writePackage(t, oldSource)
+	defer os.RemoveAll(oldDir)
+	newDir := writePackage(t, newSource)
+	defer os.RemoveAll(newDir)
+
+	old, err := describe(oldDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	new, err := describe(newDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var got []string
+	for _, c := range diff(old, new) {
+		got = append(got, c.String())
+	}
+	want := []string{
+		"breaking: type Main removed",
+		"breaking: field Wind.Deg type changed from float64 to int",
+		"added: field Wind.Gust float64",
+		"added: type Clouds",
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestRunDiffStatus(t *testing.T) {
+	dir := writePackage(t, oldSource)
+	defer os.RemoveAll(dir)
+
+	var model bytes.Buffer
+	if _, err := run([]string{"describe", dir}, &model); err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(dir, "model.json")
+	if err := ioutil.WriteFile(path, model.Bytes(), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	status, err := run([]string{"diff", path, path}, &out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if status != 0 || out.Len() != 0 {
+		t.Errorf("Expected no changes, got status %d and %q", status, out.String())
+	}
+}
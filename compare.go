@@ -0,0 +1,113 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+var errNoYesterday = errors.New("no weather data for the same time yesterday")
+
+// Change is the difference between the conditions at a location today
+// and at the same time yesterday.  Deltas are today's value minus
+// yesterday's, in the units of Unit.
+type Change struct {
+	Yesterday OneCallCurrent
+	Today     OneCallCurrent
+	Temp      float64
+	FeelsLike float64
+	Pressure  float64
+	Humidity  int
+	Clouds    int
+	WindSpeed float64
+	Unit      string
+}
+
+// Compare returns the change from yesterday to today, both given in the
+// units of unit.
+func Compare(yesterday, today OneCallCurrent, unit string) *Change {
+	return &Change{
+		Yesterday: yesterday,
+		Today:     today,
+		Temp:      today.Temp - yesterday.Temp,
+		FeelsLike: today.FeelsLike - yesterday.FeelsLike,
+		Pressure:  today.Pressure - yesterday.Pressure,
+		Humidity:  today.Humidity - yesterday.Humidity,
+		Clouds:    today.Clouds - yesterday.Clouds,
+		WindSpeed: today.WindSpeed - yesterday.WindSpeed,
+		Unit:      unit,
+	}
+}
+
+// String describes the change for people, e.g. "8°C colder than
+// yesterday, less humid, windier".  Changes too small to notice are left
+// out.
+func (c *Change) String() string {
+	temp := apiSymbol(QuantityTemperature, c.Unit)
+	parts := []string{"about as warm as yesterday"}
+	if u, _ := LookupUnit(temp); math.Abs(c.Temp)/u.Scale >= 0.5 {
+		word := "warmer"
+		if c.Temp < 0 {
+			word = "colder"
+		}
+		parts[0] = fmt.Sprintf("%s %s than yesterday", FormatValue(math.Abs(c.Temp), temp, 0), word)
+	}
+
+	switch {
+	case c.Humidity >= 10:
+		parts = append(parts, "more humid")
+	case c.Humidity <= -10:
+		parts = append(parts, "less humid")
+	}
+
+	switch wind := MetersPerSecond(c.WindSpeed, c.Unit); {
+	case wind >= 2:
+		parts = append(parts, "windier")
+	case wind <= -2:
+		parts = append(parts, "calmer")
+	}
+
+	switch {
+	case c.Clouds >= 30:
+		parts = append(parts, "cloudier")
+	case c.Clouds <= -30:
+		parts = append(parts, "clearer")
+	}
+	return strings.Join(parts, ", ")
+}
+
+// SinceYesterday returns the change in the conditions at the provided
+// location coordinates since the same time yesterday.  It uses the One
+// Call API 3.0, which requires a separate subscription.
+func (c *Client) SinceYesterday(ctx context.Context, location *Coordinates) (*Change, error) {
+	now, err := c.OneCall(ctx, location, OneCallBlockMinutely, OneCallBlockHourly, OneCallBlockDaily, OneCallBlockAlerts)
+	if err != nil {
+		return nil, err
+	}
+
+	then := now.Current.Dt.Add(-24 * time.Hour)
+	past, err := c.OneCallTimemachine(ctx, location, then)
+	if err != nil {
+		return nil, err
+	}
+	if len(past.Data) == 0 {
+		return nil, errNoYesterday
+	}
+	return Compare(past.Data[0], now.Current, c.unit), nil
+}
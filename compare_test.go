@@ -0,0 +1,79 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestChangeString(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		yesterday, today OneCallCurrent
+		unit             string
+		want             string
+	}{
+		{OneCallCurrent{Temp: 20}, OneCallCurrent{Temp: 12}, "metric", "8°C colder than yesterday"},
+		{OneCallCurrent{Temp: 50}, OneCallCurrent{Temp: 59}, "imperial", "9°F warmer than yesterday"},
+		{OneCallCurrent{Temp: 20.1}, OneCallCurrent{Temp: 20.3}, "metric", "about as warm as yesterday"},
+		{
+			OneCallCurrent{Temp: 10, Humidity: 80, WindSpeed: 1, Clouds: 90},
+			OneCallCurrent{Temp: 14, Humidity: 55, WindSpeed: 6, Clouds: 10},
+			"metric",
+			"4°C warmer than yesterday, less humid, windier, clearer",
+		},
+	}
+
+	for _, tt := range tests {
+		if got := Compare(tt.yesterday, tt.today, tt.unit).String(); got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+}
+
+func TestClientSinceYesterday(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/data/3.0/onecall":
+			if r.URL.Query().Get("exclude") != "minutely,hourly,daily,alerts" {
+				t.Errorf("unexpected exclude %q", r.URL.Query().Get("exclude"))
+			}
+			fmt.Fprint(w, `{"current":{"dt":1600086400,"temp":12,"humidity":60}}`)
+		case "/data/3.0/onecall/timemachine":
+			if r.URL.Query().Get("dt") != "1600000000" {
+				t.Errorf("Expected dt 24 hours earlier, got %s", r.URL.Query().Get("dt"))
+			}
+			fmt.Fprint(w, `{"data":[{"dt":1600000000,"temp":20,"humidity":62}]}`)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+
+	ch, err := c.SinceYesterday(context.Background(), &Coordinates{Latitude: 52.52, Longitude: 13.4})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if ch.Temp != -8 || ch.Humidity != -2 || ch.Unit != "metric" {
+		t.Errorf("unexpected change %+v", ch)
+	}
+	if ch.String() != "8°C colder than yesterday" {
+		t.Errorf("unexpected rendering %q", ch.String())
+	}
+}
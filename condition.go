@@ -0,0 +1,216 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+// ConditionID is a weather condition ID, as found in Weather.ID.  The
+// hundreds give the group of the condition, e.g. 5xx for rain.
+type ConditionID int
+
+// Weather condition IDs, see https://openweathermap.org/weather-conditions.
+const (
+	ConditionThunderstormLightRain    ConditionID = 200
+	ConditionThunderstormRain         ConditionID = 201
+	ConditionThunderstormHeavyRain    ConditionID = 202
+	ConditionLightThunderstorm        ConditionID = 210
+	ConditionThunderstorm             ConditionID = 211
+	ConditionHeavyThunderstorm        ConditionID = 212
+	ConditionRaggedThunderstorm       ConditionID = 221
+	ConditionThunderstormLightDrizzle ConditionID = 230
+	ConditionThunderstormDrizzle      ConditionID = 231
+	ConditionThunderstormHeavyDrizzle ConditionID = 232
+
+	ConditionLightDrizzle           ConditionID = 300
+	ConditionDrizzle                ConditionID = 301
+	ConditionHeavyDrizzle           ConditionID = 302
+	ConditionLightDrizzleRain       ConditionID = 310
+	ConditionDrizzleRain            ConditionID = 311
+	ConditionHeavyDrizzleRain       ConditionID = 312
+	ConditionShowerRainDrizzle      ConditionID = 313
+	ConditionHeavyShowerRainDrizzle ConditionID = 314
+	ConditionShowerDrizzle          ConditionID = 321
+
+	ConditionLightRain        ConditionID = 500
+	ConditionModerateRain     ConditionID = 501
+	ConditionHeavyRain        ConditionID = 502
+	ConditionVeryHeavyRain    ConditionID = 503
+	ConditionExtremeRain      ConditionID = 504
+	ConditionFreezingRain     ConditionID = 511
+	ConditionLightShowerRain  ConditionID = 520
+	ConditionShowerRain       ConditionID = 521
+	ConditionHeavyShowerRain  ConditionID = 522
+	ConditionRaggedShowerRain ConditionID = 531
+
+	ConditionLightSnow        ConditionID = 600
+	ConditionSnow             ConditionID = 601
+	ConditionHeavySnow        ConditionID = 602
+	ConditionSleet            ConditionID = 611
+	ConditionLightShowerSleet ConditionID = 612
+	ConditionShowerSleet      ConditionID = 613
+	ConditionLightRainSnow    ConditionID = 615
+	ConditionRainSnow         ConditionID = 616
+	ConditionLightShowerSnow  ConditionID = 620
+	ConditionShowerSnow       ConditionID = 621
+	ConditionHeavyShowerSnow  ConditionID = 622
+
+	ConditionMist        ConditionID = 701
+	ConditionSmoke       ConditionID = 711
+	ConditionHaze        ConditionID = 721
+	ConditionDustWhirls  ConditionID = 731
+	ConditionFog         ConditionID = 741
+	ConditionSand        ConditionID = 751
+	ConditionDust        ConditionID = 761
+	ConditionVolcanicAsh ConditionID = 762
+	ConditionSqualls     ConditionID = 771
+	ConditionTornado     ConditionID = 781
+
+	ConditionClear           ConditionID = 800
+	ConditionFewClouds       ConditionID = 801
+	ConditionScatteredClouds ConditionID = 802
+	ConditionBrokenClouds    ConditionID = 803
+	ConditionOvercastClouds  ConditionID = 804
+)
+
+// ConditionGroup is the group a weather condition belongs to.
+type ConditionGroup string
+
+// Condition groups returned by ConditionID.Group.
+const (
+	GroupUnknown      ConditionGroup = "unknown"
+	GroupThunderstorm ConditionGroup = "thunderstorm"
+	GroupDrizzle      ConditionGroup = "drizzle"
+	GroupRain         ConditionGroup = "rain"
+	GroupSnow         ConditionGroup = "snow"
+	GroupAtmosphere   ConditionGroup = "atmosphere"
+	GroupClear        ConditionGroup = "clear"
+	GroupClouds       ConditionGroup = "clouds"
+)
+
+// Severity ranks how intense a weather condition is.  Severities are
+// ordered, so they can be compared, e.g. s >= SeverityHeavy.
+type Severity int
+
+// Severities returned by ConditionID.Severity.
+const (
+	SeverityNone Severity = iota
+	SeverityLight
+	SeverityModerate
+	SeverityHeavy
+	SeverityExtreme
+)
+
+// String returns the name of the severity, e.g. "heavy".
+func (s Severity) String() string {
+	switch s {
+	case SeverityNone:
+		return "none"
+	case SeverityLight:
+		return "light"
+	case SeverityModerate:
+		return "moderate"
+	case SeverityHeavy:
+		return "heavy"
+	case SeverityExtreme:
+		return "extreme"
+	}
+	return "unknown"
+}
+
+// Group returns the group of the condition, or GroupUnknown for IDs
+// outside the documented ranges.
+func (id ConditionID) Group() ConditionGroup {
+	switch {
+	case id >= 200 && id < 300:
+		return GroupThunderstorm
+	case id >= 300 && id < 400:
+		return GroupDrizzle
+	case id >= 500 && id < 600:
+		return GroupRain
+	case id >= 600 && id < 700:
+		return GroupSnow
+	case id >= 700 && id < 800:
+		return GroupAtmosphere
+	case id == ConditionClear:
+		return GroupClear
+	case id > 800 && id < 900:
+		return GroupClouds
+	}
+	return GroupUnknown
+}
+
+// IsThunderstorm reports whether the condition is a thunderstorm, with
+// or without precipitation.
+func (id ConditionID) IsThunderstorm() bool {
+	return id.Group() == GroupThunderstorm
+}
+
+// IsPrecipitation reports whether precipitation reaches the ground:
+// drizzle, rain, snow and the thunderstorms with rain or drizzle.
+func (id ConditionID) IsPrecipitation() bool {
+	switch id.Group() {
+	case GroupDrizzle, GroupRain, GroupSnow:
+		return true
+	case GroupThunderstorm:
+		return id < ConditionLightThunderstorm || id >= ConditionThunderstormLightDrizzle
+	}
+	return false
+}
+
+// Severity returns how intense the condition is.  Clear skies, clouds
+// and unknown IDs are SeverityNone; volcanic ash, tornadoes and extreme
+// rain are SeverityExtreme.
+func (id ConditionID) Severity() Severity {
+	switch id {
+	case ConditionThunderstormLightRain, ConditionLightThunderstorm, ConditionThunderstormLightDrizzle,
+		ConditionLightDrizzle, ConditionLightDrizzleRain, ConditionLightRain, ConditionLightShowerRain,
+		ConditionLightSnow, ConditionLightShowerSleet, ConditionLightRainSnow, ConditionLightShowerSnow,
+		ConditionMist, ConditionHaze:
+		return SeverityLight
+	case ConditionThunderstormHeavyRain, ConditionHeavyThunderstorm, ConditionThunderstormHeavyDrizzle,
+		ConditionHeavyDrizzle, ConditionHeavyDrizzleRain, ConditionHeavyShowerRainDrizzle,
+		ConditionHeavyRain, ConditionVeryHeavyRain, ConditionFreezingRain, ConditionHeavyShowerRain,
+		ConditionHeavySnow, ConditionHeavyShowerSnow, ConditionSqualls:
+		return SeverityHeavy
+	case ConditionExtremeRain, ConditionVolcanicAsh, ConditionTornado:
+		return SeverityExtreme
+	}
+	switch id.Group() {
+	case GroupClear, GroupClouds, GroupUnknown:
+		return SeverityNone
+	}
+	return SeverityModerate
+}
+
+// Condition returns the condition ID of the entry.
+func (w Weather) Condition() ConditionID {
+	return ConditionID(w.ID)
+}
+
+// IsThunderstorm reports whether the entry is a thunderstorm, see
+// ConditionID.IsThunderstorm.
+func (w Weather) IsThunderstorm() bool {
+	return w.Condition().IsThunderstorm()
+}
+
+// IsPrecipitation reports whether precipitation reaches the ground, see
+// ConditionID.IsPrecipitation.
+func (w Weather) IsPrecipitation() bool {
+	return w.Condition().IsPrecipitation()
+}
+
+// Severity returns how intense the condition of the entry is, see
+// ConditionID.Severity.
+func (w Weather) Severity() Severity {
+	return w.Condition().Severity()
+}
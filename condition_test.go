@@ -0,0 +1,93 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import "testing"
+
+func TestConditionGroup(t *testing.T) {
+	t.Parallel()
+
+	groups := map[ConditionGroup][]*ConditionData{
+		GroupThunderstorm: ThunderstormConditions,
+		GroupDrizzle:      DrizzleConditions,
+		GroupRain:         RainConditions,
+		GroupSnow:         SnowConditions,
+		GroupAtmosphere:   AtmosphereConditions,
+	}
+	for want, conds := range groups {
+		for _, c := range conds {
+			if got := ConditionID(c.ID).Group(); got != want {
+				t.Errorf("%d (%s): expected %s, got %s", c.ID, c.Meaning, want, got)
+			}
+		}
+	}
+	if g := ConditionClear.Group(); g != GroupClear {
+		t.Errorf("Expected %s, got %s", GroupClear, g)
+	}
+	if g := ConditionOvercastClouds.Group(); g != GroupClouds {
+		t.Errorf("Expected %s, got %s", GroupClouds, g)
+	}
+	for _, id := range []ConditionID{0, 404, 900, 951} {
+		if g := id.Group(); g != GroupUnknown {
+			t.Errorf("%d: expected %s, got %s", id, GroupUnknown, g)
+		}
+	}
+}
+
+func TestConditionClassification(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id            ConditionID
+		thunderstorm  bool
+		precipitation bool
+		severity      Severity
+	}{
+		{ConditionThunderstormLightRain, true, true, SeverityLight},
+		{ConditionHeavyThunderstorm, true, false, SeverityHeavy},
+		{ConditionRaggedThunderstorm, true, false, SeverityModerate},
+		{ConditionThunderstormDrizzle, true, true, SeverityModerate},
+		{ConditionDrizzle, false, true, SeverityModerate},
+		{ConditionExtremeRain, false, true, SeverityExtreme},
+		{ConditionFreezingRain, false, true, SeverityHeavy},
+		{ConditionLightShowerSleet, false, true, SeverityLight},
+		{ConditionFog, false, false, SeverityModerate},
+		{ConditionTornado, false, false, SeverityExtreme},
+		{ConditionClear, false, false, SeverityNone},
+		{ConditionBrokenClouds, false, false, SeverityNone},
+		{999, false, false, SeverityNone},
+	}
+	for _, tt := range tests {
+		w := Weather{ID: int(tt.id)}
+		if w.IsThunderstorm() != tt.thunderstorm || w.IsPrecipitation() != tt.precipitation || w.Severity() != tt.severity {
+			t.Errorf("%d: expected %v %v %s, got %v %v %s", tt.id, tt.thunderstorm, tt.precipitation, tt.severity,
+				w.IsThunderstorm(), w.IsPrecipitation(), w.Severity())
+		}
+	}
+}
+
+func TestSeverityString(t *testing.T) {
+	t.Parallel()
+
+	if s := SeverityHeavy.String(); s != "heavy" {
+		t.Errorf("Expected heavy, got %s", s)
+	}
+	if s := Severity(9).String(); s != "unknown" {
+		t.Errorf("Expected unknown, got %s", s)
+	}
+	if !(SeverityExtreme > SeverityHeavy && SeverityLight > SeverityNone) {
+		t.Error("Expected severities to be ordered")
+	}
+}
@@ -14,13 +14,6 @@
 
 package openweathermap
 
-import (
-	"fmt"
-	"io"
-	"net/http"
-	"os"
-)
-
 // IconData holds the relevant info for linking icons to conditions.
 type IconData struct {
 	Condition string
@@ -36,34 +29,11 @@ type ConditionData struct {
 	Icon2   string
 }
 
-// RetrieveIcon will get the specified icon from the API.
+// RetrieveIcon will get the specified icon from the API and store it in
+// destination, unless it's already there.  Only successful, complete
+// downloads are stored.
 func RetrieveIcon(destination, iconFile string) (int64, error) {
-	fullFilePath := fmt.Sprintf("%s/%s", destination, iconFile)
-
-	// Check to see if we've already gotten that icon file.  If so, use it
-	// rather than getting it again.
-	if _, err := os.Stat(fullFilePath); err != nil {
-		response, err := http.Get(fmt.Sprintf(iconURL, iconFile))
-		if err != nil {
-			return 0, err
-		}
-		defer response.Body.Close()
-
-		// Create the icon file
-		out, err := os.Create(fullFilePath)
-		if err != nil {
-			return 0, err
-		}
-		defer out.Close()
-
-		// Fill the empty file with the actual content
-		n, err := io.Copy(out, response.Body)
-		if err != nil {
-			return 0, err
-		}
-		return n, nil
-	}
-	return 0, nil
+	return retrieveIcon(NewSettings(), destination, iconFile)
 }
 
 // IconList is a slice of IconData pointers
@@ -23,26 +23,31 @@ import (
 // TestRetrieveIcon will test the retrieval of icons from the API.
 func TestRetrieveIcon(t *testing.T) {
 	tmpDir := "/tmp"
-	iconFiles := []string{"01d.png", "n7m.png"}
-
-	for _, iconFile := range iconFiles {
-		size, err := RetrieveIcon(tmpDir, iconFile)
-		if err != nil {
-			t.Error(err)
-		}
-
-		f, err := os.Stat(fmt.Sprintf("%s/%s", tmpDir, iconFile))
-		if err != nil {
-			t.Error(err)
-		}
-
-		if f.Size() != size {
-			t.Error("Size of downloaded file does not match actual size of file")
-		}
-
-		err = os.Remove(fmt.Sprintf("%s/%s", tmpDir, iconFile))
-		if err != nil {
-			t.Error(err)
-		}
+	iconFile := "01d.png"
+
+	size, err := RetrieveIcon(tmpDir, iconFile)
+	if err != nil {
+		t.Error(err)
+	}
+
+	f, err := os.Stat(fmt.Sprintf("%s/%s", tmpDir, iconFile))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if f.Size() != size {
+		t.Error("Size of downloaded file does not match actual size of file")
+	}
+
+	err = os.Remove(fmt.Sprintf("%s/%s", tmpDir, iconFile))
+	if err != nil {
+		t.Error(err)
+	}
+
+	if _, err := RetrieveIcon(tmpDir, "n7m.png"); err == nil {
+		t.Error("Expected an error for an unknown icon")
+	}
+	if _, err := os.Stat(fmt.Sprintf("%s/%s", tmpDir, "n7m.png")); !os.IsNotExist(err) {
+		t.Error("Expected no file for an unknown icon")
 	}
 }
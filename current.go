@@ -15,7 +15,6 @@
 package openweathermap
 
 import (
-	"encoding/json"
 	"fmt"
 	"net/url"
 	"strings"
@@ -24,23 +23,24 @@ import (
 // CurrentWeatherData struct contains an aggregate view of the structs
 // defined above for JSON to be unmarshaled into.
 type CurrentWeatherData struct {
-	GeoPos   Coordinates `json:"coord"`
-	Sys      Sys         `json:"sys"`
-	Base     string      `json:"base"`
-	Weather  []Weather   `json:"weather"`
-	Main     Main        `json:"main"`
-	Wind     Wind        `json:"wind"`
-	Clouds   Clouds      `json:"clouds"`
-	Rain     Rain        `json:"rain"`
-	Snow     Snow        `json:"snow"`
-	Dt       int         `json:"dt"`
-	ID       int         `json:"id"`
-	Name     string      `json:"name"`
-	Cod      int         `json:"cod"`
-	Timezone int         `json:"timezone"`
-	Unit     string
-	Lang     string
-	Key      string
+	GeoPos     Coordinates `json:"coord"`
+	Sys        Sys         `json:"sys"`
+	Base       string      `json:"base"`
+	Weather    []Weather   `json:"weather"`
+	Main       Main        `json:"main"`
+	Visibility int         `json:"visibility"`
+	Wind       Wind        `json:"wind"`
+	Clouds     Clouds      `json:"clouds"`
+	Rain       Rain        `json:"rain"`
+	Snow       Snow        `json:"snow"`
+	Dt         Timestamp   `json:"dt"`
+	ID         int         `json:"id"`
+	Name       string      `json:"name"`
+	Cod        int         `json:"cod"`
+	Timezone   int         `json:"timezone"`
+	Unit       string
+	Lang       string
+	Key        string
 	*Settings
 }
 
@@ -78,14 +78,17 @@ func NewCurrent(unit, lang, key string, options ...Option) (*CurrentWeatherData,
 
 // CurrentByName will provide the current weather with the provided
 // location name.
+//
+// Deprecated: the result is stored on the receiver, which is not safe
+// for concurrent use.  Use Client.CurrentByName instead.
 func (w *CurrentWeatherData) CurrentByName(location string) error {
-	response, err := w.client.Get(fmt.Sprintf(fmt.Sprintf(baseURL, "appid=%s&q=%s&units=%s&lang=%s"), w.Key, url.QueryEscape(location), w.Unit, w.Lang))
+	response, err := w.client.Get(fmt.Sprintf(w.dataURL("weather")+"?appid=%s&q=%s&units=%s&lang=%s", w.Key, url.QueryEscape(location), w.Unit, w.Lang))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if err := json.NewDecoder(response.Body).Decode(&w); err != nil {
+	if err := w.decode(response, &w); err != nil {
 		return err
 	}
 
@@ -94,14 +97,17 @@ func (w *CurrentWeatherData) CurrentByName(location string) error {
 
 // CurrentByCoordinates will provide the current weather with the
 // provided location coordinates.
+//
+// Deprecated: the result is stored on the receiver, which is not safe
+// for concurrent use.  Use Client.CurrentByCoordinates instead.
 func (w *CurrentWeatherData) CurrentByCoordinates(location *Coordinates) error {
-	response, err := w.client.Get(fmt.Sprintf(fmt.Sprintf(baseURL, "appid=%s&lat=%f&lon=%f&units=%s&lang=%s"), w.Key, location.Latitude, location.Longitude, w.Unit, w.Lang))
+	response, err := w.client.Get(fmt.Sprintf(w.dataURL("weather")+"?appid=%s&lat=%f&lon=%f&units=%s&lang=%s", w.Key, location.Latitude, location.Longitude, w.Unit, w.Lang))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if err = json.NewDecoder(response.Body).Decode(&w); err != nil {
+	if err = w.decode(response, &w); err != nil {
 		return err
 	}
 
@@ -110,14 +116,17 @@ func (w *CurrentWeatherData) CurrentByCoordinates(location *Coordinates) error {
 
 // CurrentByID will provide the current weather with the
 // provided location ID.
+//
+// Deprecated: the result is stored on the receiver, which is not safe
+// for concurrent use.  Use Client.CurrentByID instead.
 func (w *CurrentWeatherData) CurrentByID(id int) error {
-	response, err := w.client.Get(fmt.Sprintf(fmt.Sprintf(baseURL, "appid=%s&id=%d&units=%s&lang=%s"), w.Key, id, w.Unit, w.Lang))
+	response, err := w.client.Get(fmt.Sprintf(w.dataURL("weather")+"?appid=%s&id=%d&units=%s&lang=%s", w.Key, id, w.Unit, w.Lang))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if err = json.NewDecoder(response.Body).Decode(&w); err != nil {
+	if err = w.decode(response, &w); err != nil {
 		return err
 	}
 
@@ -126,19 +135,34 @@ func (w *CurrentWeatherData) CurrentByID(id int) error {
 
 // CurrentByZip will provide the current weather for the
 // provided zip code.
+//
+// Deprecated: the result is stored on the receiver, which is not safe
+// for concurrent use.  Use Client.CurrentByZip instead.
 func (w *CurrentWeatherData) CurrentByZip(zip int, countryCode string) error {
-	response, err := w.client.Get(fmt.Sprintf(fmt.Sprintf(baseURL, "appid=%s&zip=%d,%s&units=%s&lang=%s"), w.Key, zip, countryCode, w.Unit, w.Lang))
+	response, err := w.client.Get(fmt.Sprintf(w.dataURL("weather")+"?appid=%s&zip=%d,%s&units=%s&lang=%s", w.Key, zip, countryCode, w.Unit, w.Lang))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
-	if err = json.NewDecoder(response.Body).Decode(&w); err != nil {
+	if err = w.decode(response, &w); err != nil {
 		return err
 	}
 
 	return nil
 }
 
-// CurrentByArea will provide the current weather for the
-// provided area.
-func (w *CurrentWeatherData) CurrentByArea() {}
+// CurrentByArea always returns an error.  The current weather of an
+// area covers many cities, which doesn't fit the single result stored on
+// the receiver.
+//
+// Deprecated: use Client.CurrentByBox for the cities inside a bounding
+// box or Client.Find for the cities around a point.
+func (w *CurrentWeatherData) CurrentByArea() error {
+	return errAreaUnsupported
+}
+
+// VisibilityDistance returns the visibility, which the API reports in
+// meters up to 10 km, in kilometers or, for imperial units, in miles.
+func (w *CurrentWeatherData) VisibilityDistance() float64 {
+	return Distance(float64(w.Visibility), w.Unit)
+}
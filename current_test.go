@@ -239,4 +239,14 @@ func TestCurrentByZip(t *testing.T) {
 	}
 }
 
-func TestCurrentByArea(t *testing.T) {}
+func TestCurrentByArea(t *testing.T) {
+	t.Parallel()
+
+	c, err := NewCurrent("f", "EN", testKey)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := c.CurrentByArea(); err != errAreaUnsupported {
+		t.Errorf("Expected %v, got %v", errAreaUnsupported, err)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"encoding/csv"
+	"fmt"
+	"io"
+	"math"
+	"strconv"
+	"time"
+)
+
+// HeatingDegreeDays returns the heating degree days of a day with the
+// mean temperature mean, for the base temperature base.
+func HeatingDegreeDays(mean, base float64) float64 {
+	return math.Max(base-mean, 0)
+}
+
+// CoolingDegreeDays returns the cooling degree days of a day with the
+// mean temperature mean, for the base temperature base.
+func CoolingDegreeDays(mean, base float64) float64 {
+	return math.Max(mean-base, 0)
+}
+
+// DegreeDays holds the heating and cooling degree days of a location
+// over a month.  Days is the number of days they cover, which is less
+// than the length of the month for the current month.
+type DegreeDays struct {
+	Location Coordinates
+	Month    time.Time
+	Days     int
+	Heating  float64
+	Cooling  float64
+	Unit     string
+}
+
+// MonthlyDegreeDays returns the degree days for the provided location
+// coordinates over the calendar month of month, in the location of
+// month, for the base temperature base in the units of the Client, e.g.
+// 18 for celsius or 65 for fahrenheit.  The mean temperature of a day is
+// the average of its minimum and maximum.  Only days that are over are
+// counted.  It sends a day summary request per day, spaced by the batch
+// interval, and needs the One Call API 3.0 subscription.
+func (c *Client) MonthlyDegreeDays(ctx context.Context, location *Coordinates, month time.Time, base float64) (*DegreeDays, error) {
+	start := time.Date(month.Year(), month.Month(), 1, 0, 0, 0, 0, month.Location())
+	end := start.AddDate(0, 1, 0)
+	if today := time.Now().In(month.Location()); today.Before(end) {
+		end = time.Date(today.Year(), today.Month(), today.Day(), 0, 0, 0, 0, month.Location())
+	}
+
+	d := &DegreeDays{
+		Location: *location,
+		Month:    start,
+		Unit:     c.unit,
+	}
+	th := c.newThrottle()
+	for day := start; day.Before(end); day = day.AddDate(0, 0, 1) {
+		if err := th.wait(ctx); err != nil {
+			return nil, err
+		}
+		s, err := c.DaySummary(ctx, location, day)
+		if err != nil {
+			return nil, err
+		}
+		mean := (s.Temperature.Min + s.Temperature.Max) / 2
+		d.Heating += HeatingDegreeDays(mean, base)
+		d.Cooling += CoolingDegreeDays(mean, base)
+		d.Days++
+	}
+	return d, nil
+}
+
+// WriteDegreeDaysCSV writes rows to w as CSV with a header line, one
+// line per location and month.  The attribution is written as a comment
+// line starting with "#", after the rows unless WithAttribution places it
+// before the header line or turns it off; set the Comment field of a
+// csv.Reader to '#' to skip it.
+func WriteDegreeDaysCSV(w io.Writer, rows []*DegreeDays, options ...ExportOption) error {
+	e, err := newExportSettings(options)
+	if err != nil {
+		return err
+	}
+	if e.attribution == AttributionHeader {
+		if _, err := fmt.Fprintf(w, "# %s\n", Attribution()); err != nil {
+			return err
+		}
+	}
+
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"lat", "lon", "month", "days", "hdd", "cdd", "unit"}); err != nil {
+		return err
+	}
+	for _, d := range rows {
+		err := cw.Write([]string{
+			strconv.FormatFloat(d.Location.Latitude, 'f', -1, 64),
+			strconv.FormatFloat(d.Location.Longitude, 'f', -1, 64),
+			d.Month.Format("2006-01"),
+			strconv.Itoa(d.Days),
+			strconv.FormatFloat(d.Heating, 'f', 1, 64),
+			strconv.FormatFloat(d.Cooling, 'f', 1, 64),
+			d.Unit,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	if err := cw.Error(); err != nil {
+		return err
+	}
+
+	if e.attribution == AttributionFooter {
+		_, err = fmt.Fprintf(w, "# %s\n", Attribution())
+	}
+	return err
+}
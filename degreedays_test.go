@@ -0,0 +1,95 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestDegreeDayCalculators(t *testing.T) {
+	t.Parallel()
+
+	if got := HeatingDegreeDays(10, 18); got != 8 {
+		t.Errorf("Expected 8 heating degree days, got %v", got)
+	}
+	if got := HeatingDegreeDays(20, 18); got != 0 {
+		t.Errorf("Expected 0 heating degree days, got %v", got)
+	}
+	if got := CoolingDegreeDays(21.5, 18); got != 3.5 {
+		t.Errorf("Expected 3.5 cooling degree days, got %v", got)
+	}
+}
+
+func TestClientMonthlyDegreeDays(t *testing.T) {
+	t.Parallel()
+
+	var dates []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		date := r.URL.Query().Get("date")
+		dates = append(dates, date)
+		// Even days average 10°C, odd days 22°C.
+		if day := date[len(date)-1]; (day-'0')%2 == 0 {
+			fmt.Fprint(w, `{"temperature":{"min":6,"max":14}}`)
+			return
+		}
+		fmt.Fprint(w, `{"temperature":{"min":18,"max":26}}`)
+	}, WithBatchInterval(0))
+
+	month := time.Date(2020, time.February, 15, 0, 0, 0, 0, time.UTC)
+	loc := &Coordinates{Latitude: 51.5, Longitude: -0.12}
+	d, err := c.MonthlyDegreeDays(context.Background(), loc, month, 18)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(dates) != 29 || dates[0] != "2020-02-01" || dates[28] != "2020-02-29" {
+		t.Errorf("Expected every day of February 2020, got %v", dates)
+	}
+	// 14 even days of 8 HDD and 15 odd days of 4 CDD.
+	if d.Days != 29 || d.Heating != 112 || d.Cooling != 60 || d.Unit != "metric" {
+		t.Errorf("unexpected degree days %+v", d)
+	}
+
+	var buf bytes.Buffer
+	if err := WriteDegreeDaysCSV(&buf, []*DegreeDays{d}); err != nil {
+		t.Fatal(err)
+	}
+	want := "lat,lon,month,days,hdd,cdd,unit\n51.5,-0.12,2020-02,29,112.0,60.0,metric\n# " + Attribution() + "\n"
+	if buf.String() != want {
+		t.Errorf("Expected CSV %q, got %q", want, buf.String())
+	}
+
+	buf.Reset()
+	if err := WriteDegreeDaysCSV(&buf, []*DegreeDays{d}, WithAttribution(AttributionHeader)); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(buf.String(), "# "+Attribution()+"\nlat,lon,") {
+		t.Errorf("Expected the attribution first, got %q", buf.String())
+	}
+	buf.Reset()
+	if err := WriteDegreeDaysCSV(&buf, []*DegreeDays{d}, WithAttribution(AttributionOff)); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(buf.String(), "#") {
+		t.Errorf("Expected no attribution, got %q", buf.String())
+	}
+	if err := WriteDegreeDaysCSV(&buf, nil, WithAttribution(5)); err != errInvalidPlacement {
+		t.Errorf("Expected %v, got %v", errInvalidPlacement, err)
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"math"
+	"time"
+)
+
+// SkinType is a Fitzpatrick skin phototype.
+type SkinType int
+
+// Fitzpatrick skin phototypes, from always burns to never burns.
+const (
+	SkinTypeI SkinType = iota + 1
+	SkinTypeII
+	SkinTypeIII
+	SkinTypeIV
+	SkinTypeV
+	SkinTypeVI
+)
+
+// SunProtectionUVIndex is the UV index from which the WHO recommends sun
+// protection.
+const SunProtectionUVIndex = 3
+
+const (
+	// uvIndexIrradiance is the erythemal irradiance of a UV index of 1,
+	// in W/m².
+	uvIndexIrradiance = 0.025
+	// uvZenithExponent relates the clear sky UV index to the cosine of
+	// the solar zenith angle (Madronich, 2007).
+	uvZenithExponent = 2.42
+)
+
+// minimalErythemaDose holds the erythemal dose, in J/m², that reddens
+// the skin of each skin type.
+var minimalErythemaDose = map[SkinType]float64{
+	SkinTypeI:   200,
+	SkinTypeII:  250,
+	SkinTypeIII: 350,
+	SkinTypeIV:  450,
+	SkinTypeV:   600,
+	SkinTypeVI:  1000,
+}
+
+// ExposureDay holds the sun exposure advice for one day of a UV index
+// forecast.  AvoidFrom and AvoidTo are zero when the UV index stays
+// below SunProtectionUVIndex.
+type ExposureDay struct {
+	Date        time.Time
+	UVIndex     float64
+	MaxExposure time.Duration
+	AvoidFrom   time.Time
+	AvoidTo     time.Time
+}
+
+// MaxExposure returns how long unprotected skin of the given type can
+// stay in the sun at the UV index uvi before it burns.  It returns 0,
+// meaning no limit, when uvi isn't positive or skin isn't a known type.
+func MaxExposure(uvi float64, skin SkinType) time.Duration {
+	med, ok := minimalErythemaDose[skin]
+	if !ok || uvi <= 0 {
+		return 0
+	}
+	seconds := med / (uvi * uvIndexIrradiance)
+	return time.Duration(seconds * float64(time.Second)).Round(time.Minute)
+}
+
+// SunElevation returns the elevation of the sun above the horizon, in
+// degrees, at location and time t.  It's accurate to about a degree,
+// enough for exposure planning.
+func SunElevation(location Coordinates, t time.Time) float64 {
+	const rad = math.Pi / 180
+	j2000 := time.Date(2000, time.January, 1, 12, 0, 0, 0, time.UTC)
+	d := t.Sub(j2000).Hours() / 24
+
+	g := (357.529 + 0.98560028*d) * rad
+	q := 280.459 + 0.98564736*d
+	l := (q + 1.915*math.Sin(g) + 0.020*math.Sin(2*g)) * rad
+	e := (23.439 - 0.00000036*d) * rad
+
+	ra := math.Atan2(math.Cos(e)*math.Sin(l), math.Cos(l)) / rad
+	decl := math.Asin(math.Sin(e) * math.Sin(l))
+	gmst := 18.697374558 + 24.06570982441908*d
+	ha := (gmst*15 + location.Longitude - ra) * rad
+
+	lat := location.Latitude * rad
+	return math.Asin(math.Sin(lat)*math.Sin(decl)+math.Cos(lat)*math.Cos(decl)*math.Cos(ha)) / rad
+}
+
+// AvoidSunWindow returns the period of the day around date when the UV
+// index at location is at least limit, given the day's maximum UV index
+// uvMax.  The UV index through the day is estimated from the height of
+// the sun for clear skies.  ok is false if the UV index stays below
+// limit.  date is the time the UV index forecast is given for; the day
+// spans the 12 hours before and after it.
+func AvoidSunWindow(location Coordinates, date time.Time, uvMax, limit float64) (from, to time.Time, ok bool) {
+	start := date.Add(-12 * time.Hour)
+	const steps = 24 * 60
+
+	elevations := make([]float64, steps+1)
+	noon := 0
+	for i := range elevations {
+		elevations[i] = SunElevation(location, start.Add(time.Duration(i)*time.Minute))
+		if elevations[i] > elevations[noon] {
+			noon = i
+		}
+	}
+	peak := math.Sin(elevations[noon] * math.Pi / 180)
+	uvAt := func(i int) float64 {
+		if elevations[i] <= 0 {
+			return 0
+		}
+		return uvMax * math.Pow(math.Sin(elevations[i]*math.Pi/180)/peak, uvZenithExponent)
+	}
+
+	if peak <= 0 || uvAt(noon) < limit {
+		return time.Time{}, time.Time{}, false
+	}
+	first, last := noon, noon
+	for first > 0 && uvAt(first-1) >= limit {
+		first--
+	}
+	for last < steps && uvAt(last+1) >= limit {
+		last++
+	}
+	return start.Add(time.Duration(first) * time.Minute), start.Add(time.Duration(last) * time.Minute), true
+}
+
+// ExposurePlan returns the sun exposure advice for the next cnt days, 1
+// to 8, at the provided location coordinates for the given skin type,
+// from the UV index forecast.  MaxExposure is for the peak UV index of
+// the day.
+func (c *Client) ExposurePlan(ctx context.Context, location *Coordinates, skin SkinType, cnt int) ([]ExposureDay, error) {
+	forecast, err := c.UVIndexForecast(ctx, location, cnt)
+	if err != nil {
+		return nil, err
+	}
+
+	days := make([]ExposureDay, len(forecast))
+	for i, f := range forecast {
+		d := ExposureDay{
+			Date:        f.Date.Time,
+			UVIndex:     f.Value,
+			MaxExposure: MaxExposure(f.Value, skin),
+		}
+		if from, to, ok := AvoidSunWindow(*location, d.Date, f.Value, SunProtectionUVIndex); ok {
+			d.AvoidFrom, d.AvoidTo = from, to
+		}
+		days[i] = d
+	}
+	return days, nil
+}
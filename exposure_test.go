@@ -0,0 +1,94 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestMaxExposure(t *testing.T) {
+	t.Parallel()
+
+	if got := MaxExposure(8, SkinTypeII); got != 21*time.Minute {
+		t.Errorf("Expected 21m, got %v", got)
+	}
+	if got := MaxExposure(4, SkinTypeVI); got != 167*time.Minute {
+		t.Errorf("Expected 2h47m, got %v", got)
+	}
+	if got := MaxExposure(0, SkinTypeI); got != 0 {
+		t.Errorf("Expected no limit, got %v", got)
+	}
+}
+
+func TestSunElevation(t *testing.T) {
+	t.Parallel()
+
+	// Solar noon at Greenwich on the June solstice: 90 - 51.48 + 23.44.
+	greenwich := Coordinates{Latitude: 51.48, Longitude: 0}
+	noon := time.Date(2020, time.June, 20, 12, 2, 0, 0, time.UTC)
+	if got := SunElevation(greenwich, noon); math.Abs(got-61.96) > 0.5 {
+		t.Errorf("Expected about 62°, got %v", got)
+	}
+	if got := SunElevation(greenwich, noon.Add(12*time.Hour)); got > 0 {
+		t.Errorf("Expected the sun below the horizon at midnight, got %v", got)
+	}
+}
+
+func TestAvoidSunWindow(t *testing.T) {
+	t.Parallel()
+
+	greenwich := Coordinates{Latitude: 51.48, Longitude: 0}
+	date := time.Date(2020, time.June, 20, 12, 0, 0, 0, time.UTC)
+	from, to, ok := AvoidSunWindow(greenwich, date, 7, SunProtectionUVIndex)
+	if !ok {
+		t.Fatal("Expected a window")
+	}
+	if from.Hour() < 8 || from.Hour() > 10 || to.Hour() < 14 || to.Hour() > 16 {
+		t.Errorf("Expected a window around solar noon, got %v to %v", from, to)
+	}
+	if noon := from.Add(to.Sub(from) / 2); noon.Sub(date) > 10*time.Minute || date.Sub(noon) > 10*time.Minute {
+		t.Errorf("Expected the window centered on solar noon, got %v", noon)
+	}
+
+	if _, _, ok := AvoidSunWindow(greenwich, date, 2, SunProtectionUVIndex); ok {
+		t.Error("Expected no window below the limit")
+	}
+}
+
+func TestClientExposurePlan(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"lat":51.48,"lon":0,"date_iso":"2020-06-20T12:00:00Z","date":1592654400,"value":7},{"lat":51.48,"lon":0,"date_iso":"2020-06-21T12:00:00Z","date":1592740800,"value":1.5}]`)
+	})
+
+	days, err := c.ExposurePlan(context.Background(), &Coordinates{Latitude: 51.48}, SkinTypeIII, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(days) != 2 {
+		t.Fatalf("Expected 2 days, got %d", len(days))
+	}
+	if d := days[0]; d.MaxExposure != 33*time.Minute || d.AvoidFrom.IsZero() || !d.AvoidTo.After(d.AvoidFrom) {
+		t.Errorf("unexpected first day %+v", d)
+	}
+	if d := days[1]; !d.AvoidFrom.IsZero() || !d.AvoidTo.IsZero() {
+		t.Errorf("Expected no window on the second day, got %+v", d)
+	}
+}
@@ -0,0 +1,60 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"strconv"
+)
+
+var errFindCount = errors.New("find count out of range")
+
+// maxFindCount is the largest number of cities /find returns.
+const maxFindCount = 50
+
+// FindData holds the current weather for the cities around a point, as
+// returned by the /find endpoint, nearest first.
+type FindData struct {
+	COD     string               `json:"cod"`
+	Message string               `json:"message"`
+	Count   int                  `json:"count"`
+	List    []CurrentWeatherData `json:"list"`
+	Unit    string
+	Lang    string
+}
+
+// Find returns the current weather for up to cnt cities, 1 to 50, around
+// the provided location coordinates.  The Unit and Lang of every city
+// are set like those of the result.
+func (c *Client) Find(ctx context.Context, location *Coordinates, cnt int) (*FindData, error) {
+	if cnt < 1 || cnt > maxFindCount {
+		return nil, errFindCount
+	}
+	params := coordinateParams(location)
+	params.Set("cnt", strconv.Itoa(cnt))
+
+	f := &FindData{
+		Unit: c.unit,
+		Lang: c.lang,
+	}
+	if err := c.get(ctx, c.dataURL("find")+"?"+c.query(params).Encode(), f); err != nil {
+		return nil, err
+	}
+	for i := range f.List {
+		f.List[i].Unit = c.unit
+		f.List[i].Lang = c.lang
+	}
+	return f, nil
+}
@@ -0,0 +1,56 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClientFind(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/find" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		want := "appid=" + testKey + "&cnt=2&lang=EN&lat=55.5&lon=37.5&units=metric"
+		if r.URL.RawQuery != want {
+			t.Errorf("Expected query %s, got %s", want, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"message":"accurate","cod":"200","count":2,"list":[
+			{"id":495260,"name":"Shcherbinka","coord":{"lat":55.4997,"lon":37.5597},"main":{"temp":19.3,"humidity":60},"dt":1600000000,"sys":{"country":"RU"},"weather":[{"id":800,"main":"Clear","description":"clear sky","icon":"01d"}]},
+			{"id":564517,"name":"Dubrovka","coord":{"lat":55.4667,"lon":37.5},"main":{"temp":19.1,"humidity":62},"dt":1600000000,"sys":{"country":"RU"},"weather":[]}
+		]}`)
+	})
+
+	f, err := c.Find(context.Background(), &Coordinates{Latitude: 55.5, Longitude: 37.5}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.Count != 2 || len(f.List) != 2 {
+		t.Fatalf("Expected 2 cities, got %+v", f)
+	}
+	if city := f.List[0]; city.Name != "Shcherbinka" || city.Main.Temp != 19.3 || city.Sys.Country != "RU" || city.Unit != "metric" || city.Lang != "EN" {
+		t.Errorf("unexpected city %+v", city)
+	}
+
+	for _, cnt := range []int{0, 51} {
+		if _, err := c.Find(context.Background(), &Coordinates{}, cnt); err != errFindCount {
+			t.Errorf("Expected %v for cnt %d, got %v", errFindCount, cnt, err)
+		}
+	}
+}
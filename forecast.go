@@ -44,6 +44,7 @@ type City struct {
 	Coord      Coordinates `json:"coord"`
 	Country    string      `json:"country"`
 	Population int         `json:"population"`
+	Timezone   int         `json:"timezone"`
 	Sys        ForecastSys `json:"sys"`
 }
 
@@ -59,6 +60,10 @@ type ForecastWeatherJson interface {
 	Decode(r io.Reader) error
 }
 
+// forecastQuery is the query of the legacy forecast requests, formatted
+// with the key, the location parameter, units, language and count.
+const forecastQuery = "?appid=%s&%s&mode=json&units=%s&lang=%s&cnt=%d"
+
 type ForecastWeatherData struct {
 	Unit    string
 	Lang    string
@@ -104,10 +109,10 @@ func NewForecast(forecastType, unit, lang, key string, options ...Option) (*Fore
 	}
 
 	if forecastType == "16" {
-		forecastData.baseURL = forecast16Base
+		forecastData.baseURL = settings.dataURL("forecast/daily") + forecastQuery
 		forecastData.ForecastWeatherJson = &Forecast16WeatherData{}
 	} else {
-		forecastData.baseURL = forecast5Base
+		forecastData.baseURL = settings.dataURL("forecast") + forecastQuery
 		forecastData.ForecastWeatherJson = &Forecast5WeatherData{}
 	}
 
@@ -116,6 +121,10 @@ func NewForecast(forecastType, unit, lang, key string, options ...Option) (*Fore
 
 // DailyByName will provide a forecast for the location given for the
 // number of days given.
+//
+// Deprecated: the result is stored on the receiver, which is not safe
+// for concurrent use.  Use Client.Forecast5ByName or
+// Client.Forecast16ByName instead.
 func (f *ForecastWeatherData) DailyByName(location string, days int) error {
 	response, err := f.client.Get(fmt.Sprintf(f.baseURL, f.Key, fmt.Sprintf("%s=%s", "q", url.QueryEscape(location)), f.Unit, f.Lang, days))
 	if err != nil {
@@ -123,11 +132,15 @@ func (f *ForecastWeatherData) DailyByName(location string, days int) error {
 	}
 	defer response.Body.Close()
 
-	return f.ForecastWeatherJson.Decode(response.Body)
+	return f.decode(response, f.ForecastWeatherJson)
 }
 
 // DailyByCoordinates will provide a forecast for the coordinates ID give
 // for the number of days given.
+//
+// Deprecated: the result is stored on the receiver, which is not safe
+// for concurrent use.  Use Client.Forecast5ByCoordinates or
+// Client.Forecast16ByCoordinates instead.
 func (f *ForecastWeatherData) DailyByCoordinates(location *Coordinates, days int) error {
 	response, err := f.client.Get(fmt.Sprintf(f.baseURL, f.Key, fmt.Sprintf("lat=%f&lon=%f", location.Latitude, location.Longitude), f.Unit, f.Lang, days))
 	if err != nil {
@@ -135,11 +148,15 @@ func (f *ForecastWeatherData) DailyByCoordinates(location *Coordinates, days int
 	}
 	defer response.Body.Close()
 
-	return f.ForecastWeatherJson.Decode(response.Body)
+	return f.decode(response, f.ForecastWeatherJson)
 }
 
 // DailyByID will provide a forecast for the location ID give for the
 // number of days given.
+//
+// Deprecated: the result is stored on the receiver, which is not safe
+// for concurrent use.  Use Client.Forecast5ByID or
+// Client.Forecast16ByID instead.
 func (f *ForecastWeatherData) DailyByID(id, days int) error {
 	response, err := f.client.Get(fmt.Sprintf(f.baseURL, f.Key, fmt.Sprintf("%s=%s", "id", strconv.Itoa(id)), f.Unit, f.Lang, days))
 	if err != nil {
@@ -147,10 +164,14 @@ func (f *ForecastWeatherData) DailyByID(id, days int) error {
 	}
 	defer response.Body.Close()
 
-	return f.ForecastWeatherJson.Decode(response.Body)
+	return f.decode(response, f.ForecastWeatherJson)
 }
 
 // DailyByZip will provide a forecast for the provided zip code.
+//
+// Deprecated: the result is stored on the receiver, which is not safe
+// for concurrent use.  Use Client.Forecast5ByZip or
+// Client.Forecast16ByZip instead.
 func (f *ForecastWeatherData) DailyByZip(zip int, countryCode string, days int) error {
 	response, err := f.client.Get(fmt.Sprintf(f.baseURL, f.Key, fmt.Sprintf("zip=%d,%s", zip, countryCode), f.Unit, f.Lang, days))
 	if err != nil {
@@ -158,5 +179,5 @@ func (f *ForecastWeatherData) DailyByZip(zip int, countryCode string, days int)
 	}
 	defer response.Body.Close()
 
-	return f.ForecastWeatherJson.Decode(response.Body)
+	return f.decode(response, f.ForecastWeatherJson)
 }
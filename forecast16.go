@@ -1,28 +1,34 @@
 package openweathermap
 
 import (
+	"context"
 	"encoding/json"
 	"io"
+	"net/url"
+	"strconv"
 )
 
 // Forecast16WeatherList holds specific query data
 type Forecast16WeatherList struct {
-	Dt       int         `json:"dt"`
-	Temp     Temperature `json:"temp"`
-	Pressure float64     `json:"pressure"`
-	Humidity int         `json:"humidity"`
-	Weather  []Weather   `json:"weather"`
-	Speed    float64     `json:"speed"`
-	Deg      int         `json:"deg"`
-	Clouds   int         `json:"clouds"`
-	Snow     float64     `json:"snow"`
-	Rain     float64     `json:"rain"`
+	Dt        Timestamp   `json:"dt"`
+	Temp      Temperature `json:"temp"`
+	FeelsLike Temperature `json:"feels_like"`
+	Pressure  float64     `json:"pressure"`
+	Humidity  int         `json:"humidity"`
+	Weather   []Weather   `json:"weather"`
+	Speed     float64     `json:"speed"`
+	Deg       int         `json:"deg"`
+	Gust      float64     `json:"gust"`
+	Clouds    int         `json:"clouds"`
+	Pop       float64     `json:"pop"`
+	Snow      float64     `json:"snow"`
+	Rain      float64     `json:"rain"`
 }
 
 // Forecast16WeatherData will hold returned data from queries
 type Forecast16WeatherData struct {
-	COD     int                     `json:"cod"`
-	Message string                  `json:"message"`
+	COD     string                  `json:"cod"`
+	Message float64                 `json:"message"`
 	City    City                    `json:"city"`
 	Cnt     int                     `json:"cnt"`
 	List    []Forecast16WeatherList `json:"list"`
@@ -34,3 +40,49 @@ func (f *Forecast16WeatherData) Decode(r io.Reader) error {
 	}
 	return nil
 }
+
+// forecast16 requests the daily forecast for cnt days, at most 16, for
+// the given location parameters.
+func (c *Client) forecast16(ctx context.Context, params url.Values, cnt int) (*Forecast16WeatherData, error) {
+	if c.xml {
+		return nil, errXMLUnsupported
+	}
+	if cnt < 1 || cnt > 16 {
+		return nil, errForecastCount
+	}
+	params.Set("cnt", strconv.Itoa(cnt))
+
+	f := &Forecast16WeatherData{}
+	if err := c.get(ctx, c.dataURL("forecast/daily")+"?"+c.query(params).Encode(), f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Forecast16ByName returns the daily forecast for the next cnt days,
+// 1 to 16, for the provided location name.
+func (c *Client) Forecast16ByName(ctx context.Context, location string, cnt int) (*Forecast16WeatherData, error) {
+	return c.forecast16(ctx, url.Values{"q": {location}}, cnt)
+}
+
+// Forecast16ByCoordinates returns the daily forecast for the next cnt
+// days, 1 to 16, for the provided location coordinates.
+func (c *Client) Forecast16ByCoordinates(ctx context.Context, location *Coordinates, cnt int) (*Forecast16WeatherData, error) {
+	return c.forecast16(ctx, coordinateParams(location), cnt)
+}
+
+// Forecast16ByID returns the daily forecast for the next cnt days, 1 to
+// 16, for the provided location ID.
+func (c *Client) Forecast16ByID(ctx context.Context, id, cnt int) (*Forecast16WeatherData, error) {
+	return c.forecast16(ctx, url.Values{"id": {strconv.Itoa(id)}}, cnt)
+}
+
+// Forecast16ByZip returns the daily forecast for the next cnt days, 1 to
+// 16, for the provided zip code.
+func (c *Client) Forecast16ByZip(ctx context.Context, zip, countryCode string, cnt int) (*Forecast16WeatherData, error) {
+	params, err := zipParams(zip, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	return c.forecast16(ctx, params, cnt)
+}
@@ -1,8 +1,11 @@
 package openweathermap
 
 import (
+	"context"
 	"encoding/json"
 	"io"
+	"net/url"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -18,19 +21,21 @@ func (dt *DtTxt) UnmarshalJSON(b []byte) error {
 }
 
 func (t *DtTxt) MarshalJSON() ([]byte, error) {
-	return json.Marshal(t)
+	return json.Marshal(t.Format("2006-01-02 15:04:05"))
 }
 
 // Forecast5WeatherList holds specific query data
 type Forecast5WeatherList struct {
-	Dt      int       `json:"dt"`
-	Main    Main      `json:"main"`
-	Weather []Weather `json:"weather"`
-	Clouds  Clouds    `json:"clouds"`
-	Wind    Wind      `json:"wind"`
-	Rain    Rain      `json:"rain"`
-	Snow    Snow      `json:"snow"`
-	DtTxt   DtTxt     `json:"dt_txt"`
+	Dt         Timestamp `json:"dt"`
+	Main       Main      `json:"main"`
+	Weather    []Weather `json:"weather"`
+	Clouds     Clouds    `json:"clouds"`
+	Wind       Wind      `json:"wind"`
+	Visibility int       `json:"visibility"`
+	Pop        float64   `json:"pop"`
+	Rain       Rain      `json:"rain"`
+	Snow       Snow      `json:"snow"`
+	DtTxt      DtTxt     `json:"dt_txt"`
 }
 
 // Forecast5WeatherData will hold returned data from queries
@@ -48,3 +53,53 @@ func (f *Forecast5WeatherData) Decode(r io.Reader) error {
 	}
 	return nil
 }
+
+// maxForecast5Count is the number of 3 hour entries covered by the 5 day
+// forecast.
+const maxForecast5Count = 40
+
+// forecast5 requests the 5 day forecast, limited to cnt 3 hour entries,
+// for the given location parameters.
+func (c *Client) forecast5(ctx context.Context, params url.Values, cnt int) (*Forecast5WeatherData, error) {
+	if c.xml {
+		return nil, errXMLUnsupported
+	}
+	if cnt < 1 || cnt > maxForecast5Count {
+		return nil, errForecastCount
+	}
+	params.Set("cnt", strconv.Itoa(cnt))
+
+	f := &Forecast5WeatherData{}
+	if err := c.get(ctx, c.dataURL("forecast")+"?"+c.query(params).Encode(), f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Forecast5ByName returns the first cnt 3 hour entries, 1 to 40, of the
+// 5 day forecast for the provided location name.
+func (c *Client) Forecast5ByName(ctx context.Context, location string, cnt int) (*Forecast5WeatherData, error) {
+	return c.forecast5(ctx, url.Values{"q": {location}}, cnt)
+}
+
+// Forecast5ByCoordinates returns the first cnt 3 hour entries, 1 to 40,
+// of the 5 day forecast for the provided location coordinates.
+func (c *Client) Forecast5ByCoordinates(ctx context.Context, location *Coordinates, cnt int) (*Forecast5WeatherData, error) {
+	return c.forecast5(ctx, coordinateParams(location), cnt)
+}
+
+// Forecast5ByID returns the first cnt 3 hour entries, 1 to 40, of the 5
+// day forecast for the provided location ID.
+func (c *Client) Forecast5ByID(ctx context.Context, id, cnt int) (*Forecast5WeatherData, error) {
+	return c.forecast5(ctx, url.Values{"id": {strconv.Itoa(id)}}, cnt)
+}
+
+// Forecast5ByZip returns the first cnt 3 hour entries, 1 to 40, of the 5
+// day forecast for the provided zip code.
+func (c *Client) Forecast5ByZip(ctx context.Context, zip, countryCode string, cnt int) (*Forecast5WeatherData, error) {
+	params, err := zipParams(zip, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	return c.forecast5(ctx, params, cnt)
+}
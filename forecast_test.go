@@ -15,6 +15,8 @@
 package openweathermap
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"reflect"
@@ -178,3 +180,140 @@ func TestDailyByID(t *testing.T) {
 		}
 	}
 }
+
+func TestDtTxtMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	var dt DtTxt
+	if err := dt.UnmarshalJSON([]byte(`"2020-05-15 12:00:00"`)); err != nil {
+		t.Fatal(err)
+	}
+
+	b, err := dt.MarshalJSON()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `"2020-05-15 12:00:00"` {
+		t.Errorf("Expected the dt_txt format, got %s", b)
+	}
+}
+
+func TestClientForecast16(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/forecast/daily" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `{"cod":"200","message":0.0893,"city":{"id":2643743,"name":"London"},"cnt":2,"list":[
+{"dt":1589544000,"temp":{"day":18.2,"min":9.1,"max":19.4},"humidity":55,"speed":4.1,"deg":240},
+{"dt":1589630400,"temp":{"day":16.3,"min":8.4,"max":17.9},"feels_like":{"day":15.1,"night":7.2,"eve":13.9,"morn":8},"humidity":61,"speed":5.2,"deg":250,"gust":9.8,"pop":0.62}]}`)
+	})
+
+	ctx := context.Background()
+	calls := []func() (*Forecast16WeatherData, error){
+		func() (*Forecast16WeatherData, error) { return c.Forecast16ByName(ctx, "London", 2) },
+		func() (*Forecast16WeatherData, error) {
+			return c.Forecast16ByCoordinates(ctx, &Coordinates{Latitude: 51.51, Longitude: -0.13}, 2)
+		},
+		func() (*Forecast16WeatherData, error) { return c.Forecast16ByID(ctx, 2643743, 2) },
+	}
+	for _, call := range calls {
+		f, err := call()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.City.Name != "London" || len(f.List) != 2 || f.List[1].Temp.Max != 17.9 || f.List[1].FeelsLike.Day != 15.1 || f.List[1].Gust != 9.8 || f.List[1].Pop != 0.62 {
+			t.Errorf("unexpected result %+v", f)
+		}
+		if err := f.Validate(); err != nil {
+			t.Error(err)
+		}
+	}
+
+	want := []string{
+		"appid=" + testKey + "&cnt=2&lang=EN&q=London&units=metric",
+		"appid=" + testKey + "&cnt=2&lang=EN&lat=51.51&lon=-0.13&units=metric",
+		"appid=" + testKey + "&cnt=2&id=2643743&lang=EN&units=metric",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("Expected queries %v, got %v", want, queries)
+	}
+
+	for _, cnt := range []int{0, 17} {
+		if _, err := c.Forecast16ByID(ctx, 2643743, cnt); err != errForecastCount {
+			t.Errorf("Expected %v for cnt %d, got %v", errForecastCount, cnt, err)
+		}
+	}
+}
+
+func TestClientForecast5(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/forecast" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `{"cod":"200","message":0,"cnt":2,"city":{"id":4560349,"name":"Philadelphia"},"list":[
+{"dt":1589544000,"main":{"temp":18.2},"pop":0.2,"dt_txt":"2020-05-15 12:00:00"},
+{"dt":1589554800,"main":{"temp":19.4,"feels_like":18.7},"wind":{"speed":3.1,"deg":200,"gust":7.4},"snow":{"3h":2.5},"pop":0.4,"dt_txt":"2020-05-15 15:00:00"}]}`)
+	})
+
+	ctx := context.Background()
+	calls := []func() (*Forecast5WeatherData, error){
+		func() (*Forecast5WeatherData, error) { return c.Forecast5ByName(ctx, "Philadelphia", 2) },
+		func() (*Forecast5WeatherData, error) {
+			return c.Forecast5ByCoordinates(ctx, &Coordinates{Latitude: 39.95, Longitude: -75.16}, 2)
+		},
+		func() (*Forecast5WeatherData, error) { return c.Forecast5ByID(ctx, 4560349, 2) },
+		func() (*Forecast5WeatherData, error) { return c.Forecast5ByZip(ctx, "19125", "US", 2) },
+	}
+	for _, call := range calls {
+		f, err := call()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if f.City.Name != "Philadelphia" || len(f.List) != 2 || f.List[1].Pop != 0.4 || f.List[1].Main.FeelsLike != 18.7 || f.List[1].Wind.Gust != 7.4 || f.List[1].Snow.ThreeH != 2.5 {
+			t.Errorf("unexpected result %+v", f)
+		}
+	}
+
+	want := []string{
+		"appid=" + testKey + "&cnt=2&lang=EN&q=Philadelphia&units=metric",
+		"appid=" + testKey + "&cnt=2&lang=EN&lat=39.95&lon=-75.16&units=metric",
+		"appid=" + testKey + "&cnt=2&id=4560349&lang=EN&units=metric",
+		"appid=" + testKey + "&cnt=2&lang=EN&units=metric&zip=19125%2CUS",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("Expected queries %v, got %v", want, queries)
+	}
+
+	for _, cnt := range []int{0, 41} {
+		if _, err := c.Forecast5ByID(ctx, 4560349, cnt); err != errForecastCount {
+			t.Errorf("Expected %v for cnt %d, got %v", errForecastCount, cnt, err)
+		}
+	}
+}
+
+func TestClientForecast16ByZip(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/forecast/daily" || r.URL.Query().Get("zip") != "02134,US" || r.URL.Query().Get("cnt") != "7" {
+			t.Errorf("unexpected url %s", r.URL)
+		}
+		fmt.Fprint(w, `{"cod":"200","city":{"name":"Allston"},"cnt":0,"list":[]}`)
+	})
+
+	f, err := c.Forecast16ByZip(context.Background(), "02134", "US", 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.City.Name != "Allston" {
+		t.Errorf("unexpected result %+v", f)
+	}
+}
@@ -0,0 +1,170 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// ErrLocationNotFound is returned when geocoding finds no location for a
+// name.
+var ErrLocationNotFound = errors.New("openweathermap: location not found")
+
+// GeoLocation is a location found by the geocoding API.
+type GeoLocation struct {
+	Name       string            `json:"name"`
+	LocalNames map[string]string `json:"local_names"`
+	Latitude   float64           `json:"lat"`
+	Longitude  float64           `json:"lon"`
+	Country    string            `json:"country"`
+	State      string            `json:"state"`
+}
+
+// Coordinates returns the coordinates of the location, for use with the
+// other Client methods.
+func (l *GeoLocation) Coordinates() *Coordinates {
+	return &Coordinates{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+// ZipLocation is the location of a zip or post code, as returned by the
+// geocoding API.
+type ZipLocation struct {
+	Zip       string  `json:"zip"`
+	Name      string  `json:"name"`
+	Latitude  float64 `json:"lat"`
+	Longitude float64 `json:"lon"`
+	Country   string  `json:"country"`
+}
+
+// Coordinates returns the coordinates of the location, for use with the
+// other Client methods.
+func (l *ZipLocation) Coordinates() *Coordinates {
+	return &Coordinates{Latitude: l.Latitude, Longitude: l.Longitude}
+}
+
+// GeocodeResult holds the outcome of geocoding one name with GeocodeAll.
+type GeocodeResult struct {
+	Name     string
+	Location *GeoLocation
+	Err      error
+}
+
+// geocodeCache holds the best match for every name GeocodeAll has
+// resolved, keyed by normalized name.
+type geocodeCache struct {
+	mu      sync.Mutex
+	entries map[string]*GeoLocation
+}
+
+func (gc *geocodeCache) get(name string) (*GeoLocation, bool) {
+	gc.mu.Lock()
+	defer gc.mu.Unlock()
+	l, ok := gc.entries[name]
+	return l, ok
+}
+
+func (gc *geocodeCache) put(name string, l *GeoLocation) {
+	gc.mu.Lock()
+	gc.entries[name] = l
+	gc.mu.Unlock()
+}
+
+// normalizeName returns the cache key for a location name.
+func normalizeName(name string) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " "))
+}
+
+// Geocode returns up to limit locations, at most 5, matching name, e.g.
+// "London", "London,GB" or "Portland,OR,US".
+func (c *Client) Geocode(ctx context.Context, name string, limit int) ([]GeoLocation, error) {
+	q := url.Values{"q": {name}, "limit": {strconv.Itoa(limit)}}
+	var l []GeoLocation
+	if err := c.get(ctx, fmt.Sprintf(geocodeURL, c.query(q).Encode()), &l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GeocodeZip returns the location of the provided zip or post code in the
+// country given by its ISO 3166 code, e.g. "90210" and "US" or "E14"
+// and "GB".
+func (c *Client) GeocodeZip(ctx context.Context, zip, countryCode string) (*ZipLocation, error) {
+	q, err := zipParams(zip, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	l := &ZipLocation{}
+	if err := c.get(ctx, fmt.Sprintf(geocodeZipURL, c.query(q).Encode()), l); err != nil {
+		return nil, err
+	}
+	return l, nil
+}
+
+// GeocodeAll returns the best matching location for each of names, in
+// the same order.  Names differing only in case or spacing are looked up
+// once, and found locations are cached by the Client, so importing the
+// same names again doesn't use any requests.  Requests are spaced by the
+// batch interval.
+//
+// A name that can't be geocoded gets its own error, e.g.
+// ErrLocationNotFound, without failing the others.  If ctx is done
+// before all names are looked up, the remaining results hold the context
+// error, which is returned as well.
+func (c *Client) GeocodeAll(ctx context.Context, names []string) ([]GeocodeResult, error) {
+	results := make([]GeocodeResult, len(names))
+	resolved := make(map[string]GeocodeResult)
+
+	th := c.newThrottle()
+	for i, name := range names {
+		key := normalizeName(name)
+		if r, ok := resolved[key]; ok {
+			results[i] = GeocodeResult{Name: name, Location: r.Location, Err: r.Err}
+			continue
+		}
+
+		r := GeocodeResult{Name: name}
+		if l, ok := c.geocache.get(key); ok {
+			r.Location = l
+		} else if err := th.wait(ctx); err != nil {
+			r.Err = err
+		} else {
+			r.Location, r.Err = c.geocodeOne(ctx, name)
+			if r.Err == nil {
+				c.geocache.put(key, r.Location)
+			}
+		}
+
+		results[i] = r
+		resolved[key] = r
+	}
+	return results, ctx.Err()
+}
+
+// geocodeOne returns the best matching location for name.
+func (c *Client) geocodeOne(ctx context.Context, name string) (*GeoLocation, error) {
+	l, err := c.Geocode(ctx, name, 1)
+	if err != nil {
+		return nil, err
+	}
+	if len(l) == 0 {
+		return nil, fmt.Errorf("%w: %s", ErrLocationNotFound, name)
+	}
+	return &l[0], nil
+}
@@ -0,0 +1,160 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestClientGeocode(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/geo/1.0/direct" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("q") != "London" || q.Get("limit") != "2" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[
+  {"name":"London","local_names":{"fr":"Londres"},"lat":51.5073219,"lon":-0.1276474,"country":"GB","state":"England"},
+  {"name":"London","lat":42.9832406,"lon":-81.243372,"country":"CA","state":"Ontario"}
+]`)
+	}, WithStrictDecoding())
+
+	l, err := c.Geocode(context.Background(), "London", 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(l) != 2 || l[0].Country != "GB" || l[0].LocalNames["fr"] != "Londres" || l[1].State != "Ontario" {
+		t.Errorf("unexpected result %+v", l)
+	}
+}
+
+func TestClientGeocodeZip(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/geo/1.0/zip" || r.URL.Query().Get("zip") != "02134,US" {
+			t.Errorf("unexpected url %s", r.URL)
+		}
+		fmt.Fprint(w, `{"zip":"02134","name":"Allston","lat":42.3537,"lon":-71.1323,"country":"US"}`)
+	}, WithStrictDecoding())
+
+	l, err := c.GeocodeZip(context.Background(), "02134", "US")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if l.Zip != "02134" || l.Name != "Allston" || l.Country != "US" {
+		t.Errorf("unexpected result %+v", l)
+	}
+	if co := l.Coordinates(); co.Latitude != 42.3537 || co.Longitude != -71.1323 {
+		t.Errorf("unexpected coordinates %+v", co)
+	}
+}
+
+func TestClientGeocodeAll(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	requests := map[string]int{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query().Get("q")
+		mu.Lock()
+		requests[q]++
+		mu.Unlock()
+		switch q {
+		case "Atlantis":
+			fmt.Fprint(w, `[]`)
+		case "Broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			fmt.Fprintf(w, `[{"name":%q,"lat":1,"lon":2}]`, q)
+		}
+	}, WithBatchInterval(0))
+
+	names := []string{"Paris", "Atlantis", " paris ", "Broken", "Oslo"}
+	results, err := c.GeocodeAll(context.Background(), names)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if len(results) != len(names) {
+		t.Fatalf("Expected %d results, got %d", len(names), len(results))
+	}
+	for i, r := range results {
+		if r.Name != names[i] {
+			t.Errorf("Expected result %d for %q, got %q", i, names[i], r.Name)
+		}
+	}
+	if results[0].Err != nil || results[0].Location.Name != "Paris" || results[2].Location != results[0].Location {
+		t.Errorf("Expected Paris to be found once, got %+v and %+v", results[0], results[2])
+	}
+	if !errors.Is(results[1].Err, ErrLocationNotFound) {
+		t.Errorf("Expected %v, got %v", ErrLocationNotFound, results[1].Err)
+	}
+	if _, ok := results[3].Err.(*APIError); !ok {
+		t.Errorf("Expected *APIError, got %v", results[3].Err)
+	}
+	if results[4].Err != nil || results[4].Location.Name != "Oslo" {
+		t.Errorf("unexpected result %+v", results[4])
+	}
+	if requests["Paris"] != 1 || requests[" paris "] != 0 {
+		t.Errorf("Expected Paris to be requested once, got %v", requests)
+	}
+
+	// Found names are served from the cache, failed ones are retried.
+	if _, err := c.GeocodeAll(context.Background(), []string{"PARIS", "Broken"}); err != nil {
+		t.Fatal(err)
+	}
+	if requests["Paris"] != 1 || requests["PARIS"] != 0 || requests["Broken"] != 2 {
+		t.Errorf("unexpected requests %v", requests)
+	}
+}
+
+func TestClientGeocodeAllRateLimit(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `[{"name":"x"}]`)
+	}, WithBatchInterval(20*time.Millisecond))
+
+	start := time.Now()
+	if _, err := c.GeocodeAll(context.Background(), []string{"a", "b", "c"}); err != nil {
+		t.Fatal(err)
+	}
+	if elapsed := time.Since(start); elapsed < 40*time.Millisecond {
+		t.Errorf("Expected requests to be spaced out, took %v", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results, err := c.GeocodeAll(ctx, []string{"a", "d"})
+	if err != context.Canceled {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+	if results[0].Err != nil || results[1].Err != context.Canceled {
+		t.Errorf("Expected the cached name and a canceled one, got %+v", results)
+	}
+
+	if _, err := NewClient("c", "en", testKey, WithBatchInterval(-time.Second)); err != errInvalidOption {
+		t.Errorf("Expected %v, but got %v", errInvalidOption, err)
+	}
+}
@@ -1,3 +1,9 @@
 module github.com/briandowns/openweathermap
 
 go 1.13
+
+require (
+	github.com/prometheus/client_golang v1.11.1
+	go.opentelemetry.io/otel v1.0.1
+	go.opentelemetry.io/otel/trace v1.0.1
+)
@@ -0,0 +1,158 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"math"
+	"time"
+)
+
+var errInvalidGrid = errors.New("invalid grid bounding box or resolution")
+var errGridTooLarge = errors.New("grid has too many cells")
+
+// MaxGridCells is the largest number of cells SampleGrid samples, to
+// guard against a resolution that would use up a whole day's requests.
+const MaxGridCells = 2500
+
+// gridCacheTTL is how long a sampled cell is reused.  OWM updates current
+// conditions about every 10 minutes.
+const gridCacheTTL = 10 * time.Minute
+
+// BoundingBox is an area between two latitudes and two longitudes, in
+// degrees.
+type BoundingBox struct {
+	South float64
+	West  float64
+	North float64
+	East  float64
+}
+
+// GridCell holds the current weather sampled at one point of a Grid.
+type GridCell struct {
+	Location Coordinates
+	Weather  *CurrentWeatherData
+	Err      error
+}
+
+// Grid holds current weather sampled over a BoundingBox.  Cells[i][j] is
+// the sample at Latitudes[i] and Longitudes[j]; rows run from north to
+// south and columns from west to east, the order of image rows and
+// pixels.
+type Grid struct {
+	Latitudes  []float64
+	Longitudes []float64
+	Cells      [][]GridCell
+}
+
+type gridEntry struct {
+	weather *CurrentWeatherData
+	fetched time.Time
+}
+
+// Values returns the matrix of fn applied to the weather of every cell,
+// e.g. the temperature for a heat map.  Cells that couldn't be sampled
+// are NaN.
+func (g *Grid) Values(fn func(w *CurrentWeatherData) float64) [][]float64 {
+	m := make([][]float64, len(g.Cells))
+	for i, row := range g.Cells {
+		m[i] = make([]float64, len(row))
+		for j, cell := range row {
+			if cell.Weather == nil {
+				m[i][j] = math.NaN()
+				continue
+			}
+			m[i][j] = fn(cell.Weather)
+		}
+	}
+	return m
+}
+
+// gridSteps returns the values from start to end, inclusive, step apart.
+func gridSteps(start, end, step float64) []float64 {
+	n := int(math.Floor((end-start)/step+1e-9)) + 1
+	v := make([]float64, n)
+	for i := range v {
+		v[i] = math.Round((start+float64(i)*step)*1e6) / 1e6
+	}
+	return v
+}
+
+// SampleGrid requests the current weather at every point of a grid over
+// box, resolution degrees apart, starting at the north west corner.
+// Requests are spaced by the batch interval and samples are reused for
+// 10 minutes, so overlapping or repeated grids don't cost requests.
+// While OWM is down for maintenance, samples are reused for 2 hours.
+//
+// A point that can't be sampled gets its own error without failing the
+// others.  If ctx is done before the grid is complete, the remaining
+// cells hold the context error, which is returned as well.
+func (c *Client) SampleGrid(ctx context.Context, box BoundingBox, resolution float64) (*Grid, error) {
+	if resolution <= 0 || box.North < box.South || box.East < box.West ||
+		box.South < -90 || box.North > 90 || box.West < -180 || box.East > 180 {
+		return nil, errInvalidGrid
+	}
+
+	lons := gridSteps(box.West, box.East, resolution)
+	lats := gridSteps(-box.North, -box.South, resolution)
+	for i := range lats {
+		lats[i] = -lats[i]
+	}
+	if len(lats)*len(lons) > MaxGridCells {
+		return nil, errGridTooLarge
+	}
+
+	g := &Grid{Latitudes: lats, Longitudes: lons, Cells: make([][]GridCell, len(lats))}
+	th := c.newThrottle()
+	for i, lat := range lats {
+		g.Cells[i] = make([]GridCell, len(lons))
+		for j, lon := range lons {
+			cell := GridCell{Location: Coordinates{Latitude: lat, Longitude: lon}}
+			if w, ok := c.cachedCell(cell.Location); ok {
+				cell.Weather = w
+			} else if err := th.wait(ctx); err != nil {
+				cell.Err = err
+			} else {
+				cell.Weather, cell.Err = c.CurrentByCoordinates(ctx, &cell.Location)
+				if cell.Err == nil {
+					c.cacheCell(cell.Location, cell.Weather)
+				}
+			}
+			g.Cells[i][j] = cell
+		}
+	}
+	return g, ctx.Err()
+}
+
+func (c *Client) cachedCell(location Coordinates) (*CurrentWeatherData, bool) {
+	c.gridMu.Lock()
+	defer c.gridMu.Unlock()
+	e, ok := c.grid[location]
+	if !ok || time.Since(e.fetched) > c.cacheTTL() {
+		return nil, false
+	}
+	return e.weather, true
+}
+
+func (c *Client) cacheCell(location Coordinates, w *CurrentWeatherData) {
+	c.gridMu.Lock()
+	defer c.gridMu.Unlock()
+	for k, e := range c.grid {
+		if time.Since(e.fetched) > gridCacheTTL {
+			delete(c.grid, k)
+		}
+	}
+	c.grid[location] = gridEntry{weather: w, fetched: time.Now()}
+}
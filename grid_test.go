@@ -0,0 +1,110 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"reflect"
+	"strconv"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientSampleGrid(t *testing.T) {
+	t.Parallel()
+
+	var requests int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		lat, _ := strconv.ParseFloat(r.URL.Query().Get("lat"), 64)
+		lon, _ := strconv.ParseFloat(r.URL.Query().Get("lon"), 64)
+		if lat == 51 && lon == 1 {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+		fmt.Fprintf(w, `{"coord":{"lat":%v,"lon":%v},"main":{"temp":%v}}`, lat, lon, lat+lon)
+	}, WithBatchInterval(0))
+
+	box := BoundingBox{South: 50.5, West: 0, North: 51.5, East: 1}
+	g, err := c.SampleGrid(context.Background(), box, 0.5)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if want := []float64{51.5, 51, 50.5}; !reflect.DeepEqual(g.Latitudes, want) {
+		t.Errorf("Expected latitudes %v, got %v", want, g.Latitudes)
+	}
+	if want := []float64{0, 0.5, 1}; !reflect.DeepEqual(g.Longitudes, want) {
+		t.Errorf("Expected longitudes %v, got %v", want, g.Longitudes)
+	}
+	if requests != 9 {
+		t.Errorf("Expected 9 requests, got %d", requests)
+	}
+	if _, ok := g.Cells[1][2].Err.(*APIError); !ok {
+		t.Errorf("Expected the failed cell to hold an *APIError, got %v", g.Cells[1][2].Err)
+	}
+
+	m := g.Values(func(w *CurrentWeatherData) float64 { return w.Main.Temp })
+	if m[0][0] != 51.5 || m[2][1] != 51 || !math.IsNaN(m[1][2]) {
+		t.Errorf("unexpected values %v", m)
+	}
+
+	// Sampled cells are reused, the failed one is retried.
+	if _, err := c.SampleGrid(context.Background(), box, 0.5); err != nil {
+		t.Fatal(err)
+	}
+	if requests != 10 {
+		t.Errorf("Expected 1 more request, got %d in total", requests)
+	}
+}
+
+func TestClientSampleGridLimits(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{}`)
+	}, WithBatchInterval(time.Hour))
+
+	invalid := []struct {
+		box        BoundingBox
+		resolution float64
+	}{
+		{BoundingBox{South: 0, West: 0, North: 1, East: 1}, 0},
+		{BoundingBox{South: 1, West: 0, North: 0, East: 1}, 0.5},
+		{BoundingBox{South: 0, West: 0, North: 91, East: 1}, 0.5},
+	}
+	for _, tt := range invalid {
+		if _, err := c.SampleGrid(context.Background(), tt.box, tt.resolution); err != errInvalidGrid {
+			t.Errorf("Expected %v for %+v, got %v", errInvalidGrid, tt, err)
+		}
+	}
+
+	if _, err := c.SampleGrid(context.Background(), BoundingBox{South: -60, West: -180, North: 60, East: 180}, 1); err != errGridTooLarge {
+		t.Errorf("Expected %v, got %v", errGridTooLarge, err)
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	g, err := c.SampleGrid(ctx, BoundingBox{South: 10, West: 10, North: 10, East: 11}, 1)
+	if err != context.DeadlineExceeded {
+		t.Errorf("Expected %v, got %v", context.DeadlineExceeded, err)
+	}
+	if g.Cells[0][0].Err != nil || g.Cells[0][1].Err != context.DeadlineExceeded {
+		t.Errorf("Expected the first cell to be sampled before the deadline, got %+v", g.Cells)
+	}
+}
@@ -0,0 +1,259 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+// Package heatmap renders matrices of values, such as the ones returned
+// by openweathermap's Grid.Values, as PNG or SVG heat maps.  Row 0 is
+// drawn at the top; NaN values are left transparent.  The maps carry the
+// OWM attribution, see WithAttribution.
+//
+//	g, err := c.SampleGrid(ctx, box, 0.25)
+//	temps := g.Values(func(w *owm.CurrentWeatherData) float64 { return w.Main.Temp })
+//	err = heatmap.PNG(f, temps, heatmap.WithCellSize(16))
+package heatmap
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/png"
+	"io"
+	"math"
+
+	owm "github.com/briandowns/openweathermap"
+)
+
+var errNoValues = errors.New("no values to render")
+var errInvalidOption = errors.New("invalid option")
+
+// ramp holds the colors values are mapped to, from the minimum to the
+// maximum.
+var ramp = []color.RGBA{
+	{R: 49, G: 54, B: 149, A: 255},
+	{R: 69, G: 117, B: 180, A: 255},
+	{R: 116, G: 173, B: 209, A: 255},
+	{R: 224, G: 243, B: 248, A: 255},
+	{R: 254, G: 224, B: 144, A: 255},
+	{R: 244, G: 109, B: 67, A: 255},
+	{R: 165, G: 0, B: 38, A: 255},
+}
+
+// attributionHeight is the height, in pixels, of the line holding the
+// attribution in SVG maps.
+const attributionHeight = 14
+
+type config struct {
+	cellSize    int
+	min, max    float64
+	fixed       bool
+	attribution owm.AttributionPlacement
+}
+
+// Option configures the rendering.
+type Option func(c *config) error
+
+// WithCellSize sets the width and height, in pixels, of every cell.  The
+// default is 10.
+func WithCellSize(n int) Option {
+	return func(c *config) error {
+		if n < 1 {
+			return errInvalidOption
+		}
+		c.cellSize = n
+		return nil
+	}
+}
+
+// WithRange sets the values mapped to the first and last color.  Values
+// outside of it are clamped.  By default the range of the values is
+// used, so use WithRange to compare maps with each other.
+func WithRange(min, max float64) Option {
+	return func(c *config) error {
+		if !(min < max) {
+			return errInvalidOption
+		}
+		c.min, c.max, c.fixed = min, max, true
+		return nil
+	}
+}
+
+// WithAttribution sets where the map carries the OWM attribution.  SVG
+// maps show it on a line below the cells, or above them with
+// owm.AttributionHeader.  PNG maps hold it in a Copyright text chunk,
+// after or before the image data.  owm.AttributionOff leaves it out.
+func WithAttribution(p owm.AttributionPlacement) Option {
+	return func(c *config) error {
+		if p < owm.AttributionFooter || p > owm.AttributionOff {
+			return errInvalidOption
+		}
+		c.attribution = p
+		return nil
+	}
+}
+
+// newConfig applies options and finds the value range if it isn't fixed.
+func newConfig(values [][]float64, options []Option) (*config, error) {
+	c := &config{cellSize: 10}
+	for _, o := range options {
+		if o == nil {
+			return nil, errInvalidOption
+		}
+		if err := o(c); err != nil {
+			return nil, err
+		}
+	}
+	if len(values) == 0 || len(values[0]) == 0 {
+		return nil, errNoValues
+	}
+	if !c.fixed {
+		c.min, c.max = math.Inf(1), math.Inf(-1)
+		for _, row := range values {
+			for _, v := range row {
+				if !math.IsNaN(v) {
+					c.min = math.Min(c.min, v)
+					c.max = math.Max(c.max, v)
+				}
+			}
+		}
+		if math.IsInf(c.min, 1) {
+			return nil, errNoValues
+		}
+	}
+	return c, nil
+}
+
+// Color returns the color of v on a scale from min to max.
+func Color(v, min, max float64) color.RGBA {
+	f := 0.0
+	if max > min {
+		f = (v - min) / (max - min)
+	}
+	f = math.Max(0, math.Min(1, f)) * float64(len(ramp)-1)
+
+	i := int(f)
+	if i >= len(ramp)-1 {
+		return ramp[len(ramp)-1]
+	}
+	a, b, t := ramp[i], ramp[i+1], f-float64(i)
+	mix := func(x, y uint8) uint8 { return uint8(math.Round(float64(x) + (float64(y)-float64(x))*t)) }
+	return color.RGBA{R: mix(a.R, b.R), G: mix(a.G, b.G), B: mix(a.B, b.B), A: 255}
+}
+
+// PNG writes values as a PNG heat map to w.
+func PNG(w io.Writer, values [][]float64, options ...Option) error {
+	c, err := newConfig(values, options)
+	if err != nil {
+		return err
+	}
+
+	img := image.NewRGBA(image.Rect(0, 0, len(values[0])*c.cellSize, len(values)*c.cellSize))
+	for i, row := range values {
+		for j, v := range row {
+			if math.IsNaN(v) {
+				continue
+			}
+			col := Color(v, c.min, c.max)
+			for y := i * c.cellSize; y < (i+1)*c.cellSize; y++ {
+				for x := j * c.cellSize; x < (j+1)*c.cellSize; x++ {
+					img.SetRGBA(x, y, col)
+				}
+			}
+		}
+	}
+	if c.attribution == owm.AttributionOff {
+		return png.Encode(w, img)
+	}
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, img); err != nil {
+		return err
+	}
+	b := buf.Bytes()
+	// The chunk goes right after the signature and IHDR chunk, or right
+	// before the IEND chunk.
+	at := len(b) - 12
+	if c.attribution == owm.AttributionHeader {
+		at = 8 + 25
+	}
+	if _, err := w.Write(b[:at]); err != nil {
+		return err
+	}
+	if _, err := w.Write(textChunk("Copyright", owm.Attribution())); err != nil {
+		return err
+	}
+	_, err = w.Write(b[at:])
+	return err
+}
+
+// textChunk returns a PNG tEXt chunk holding text under keyword.  Both
+// must be Latin-1.
+func textChunk(keyword, text string) []byte {
+	data := append([]byte(keyword), 0)
+	data = append(data, text...)
+
+	chunk := make([]byte, 8, 12+len(data))
+	binary.BigEndian.PutUint32(chunk, uint32(len(data)))
+	copy(chunk[4:], "tEXt")
+	chunk = append(chunk, data...)
+
+	var crc [4]byte
+	binary.BigEndian.PutUint32(crc[:], crc32.ChecksumIEEE(chunk[4:]))
+	return append(chunk, crc[:]...)
+}
+
+// SVG writes values as an SVG heat map to w.  Every cell is a rect
+// with a title holding its value.
+func SVG(w io.Writer, values [][]float64, options ...Option) error {
+	c, err := newConfig(values, options)
+	if err != nil {
+		return err
+	}
+
+	width, height := len(values[0])*c.cellSize, len(values)*c.cellSize
+	top, svgHeight := 0, height
+	switch c.attribution {
+	case owm.AttributionHeader:
+		top, svgHeight = attributionHeight, height+attributionHeight
+	case owm.AttributionFooter:
+		svgHeight = height + attributionHeight
+	}
+	if _, err := fmt.Fprintf(w, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d">`+"\n", width, svgHeight, width, svgHeight); err != nil {
+		return err
+	}
+	for i, row := range values {
+		for j, v := range row {
+			if math.IsNaN(v) {
+				continue
+			}
+			col := Color(v, c.min, c.max)
+			if _, err := fmt.Fprintf(w, `<rect x="%d" y="%d" width="%d" height="%d" fill="#%02x%02x%02x"><title>%g</title></rect>`+"\n",
+				j*c.cellSize, top+i*c.cellSize, c.cellSize, c.cellSize, col.R, col.G, col.B, v); err != nil {
+				return err
+			}
+		}
+	}
+	if c.attribution != owm.AttributionOff {
+		y := attributionHeight - 4
+		if c.attribution == owm.AttributionFooter {
+			y += height
+		}
+		if _, err := fmt.Fprintf(w, `<text x="2" y="%d" font-family="sans-serif" font-size="10">%s</text>`+"\n", y, owm.AttributionHTML()); err != nil {
+			return err
+		}
+	}
+	_, err = fmt.Fprintln(w, "</svg>")
+	return err
+}
@@ -0,0 +1,157 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package heatmap
+
+import (
+	"bytes"
+	"image/color"
+	"image/png"
+	"math"
+	"strings"
+	"testing"
+
+	owm "github.com/briandowns/openweathermap"
+)
+
+var values = [][]float64{
+	{10, 15, 20},
+	{12, math.NaN(), 30},
+}
+
+func TestColor(t *testing.T) {
+	t.Parallel()
+
+	if c := Color(0, 0, 1); c != ramp[0] {
+		t.Errorf("Expected the first color for the minimum, got %v", c)
+	}
+	if c := Color(1, 0, 1); c != ramp[len(ramp)-1] {
+		t.Errorf("Expected the last color for the maximum, got %v", c)
+	}
+	if c := Color(-5, 0, 1); c != ramp[0] {
+		t.Errorf("Expected values below the range to be clamped, got %v", c)
+	}
+	if c := Color(0.5, 0, 1); c != ramp[3] {
+		t.Errorf("Expected the middle color, got %v", c)
+	}
+}
+
+func TestPNG(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := PNG(&buf, values, WithCellSize(4)); err != nil {
+		t.Fatal(err)
+	}
+	img, err := png.Decode(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if b := img.Bounds(); b.Dx() != 12 || b.Dy() != 8 {
+		t.Fatalf("Expected a 12x8 image, got %v", b)
+	}
+	if c := color.RGBAModel.Convert(img.At(1, 1)); c != ramp[0] {
+		t.Errorf("Expected the minimum color at the top left, got %v", c)
+	}
+	if c := color.RGBAModel.Convert(img.At(11, 7)); c != ramp[len(ramp)-1] {
+		t.Errorf("Expected the maximum color at the bottom right, got %v", c)
+	}
+	if _, _, _, a := img.At(5, 5).RGBA(); a != 0 {
+		t.Error("Expected the NaN cell to be transparent")
+	}
+}
+
+func TestSVG(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := SVG(&buf, values, WithRange(0, 40)); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+
+	if !strings.HasPrefix(svg, `<svg xmlns="http://www.w3.org/2000/svg" width="30" height="34"`) {
+		t.Errorf("unexpected svg element %s", svg)
+	}
+	if n := strings.Count(svg, "<rect"); n != 5 {
+		t.Errorf("Expected 5 cells, got %d", n)
+	}
+	if !strings.Contains(svg, `<rect x="20" y="10" width="10" height="10" fill="#f9a76a"><title>30</title></rect>`) {
+		t.Errorf("Expected the cell of 30 on the fixed range, got %s", svg)
+	}
+	if !strings.Contains(svg, `<text x="2" y="30" font-family="sans-serif" font-size="10">`+owm.AttributionHTML()+`</text>`) {
+		t.Errorf("Expected the attribution below the cells, got %s", svg)
+	}
+}
+
+func TestSVGAttribution(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := SVG(&buf, values, WithAttribution(owm.AttributionHeader)); err != nil {
+		t.Fatal(err)
+	}
+	svg := buf.String()
+	if !strings.Contains(svg, `<rect x="0" y="14" `) || !strings.Contains(svg, `<text x="2" y="10" `) {
+		t.Errorf("Expected the attribution above the cells, got %s", svg)
+	}
+
+	buf.Reset()
+	if err := SVG(&buf, values, WithAttribution(owm.AttributionOff)); err != nil {
+		t.Fatal(err)
+	}
+	if svg := buf.String(); strings.Contains(svg, "<text") || !strings.Contains(svg, `height="20"`) {
+		t.Errorf("Expected no attribution, got %s", svg)
+	}
+}
+
+func TestPNGAttribution(t *testing.T) {
+	t.Parallel()
+
+	chunk := "tEXtCopyright\x00" + owm.Attribution()
+	for _, p := range []owm.AttributionPlacement{owm.AttributionFooter, owm.AttributionHeader, owm.AttributionOff} {
+		var buf bytes.Buffer
+		if err := PNG(&buf, values, WithAttribution(p)); err != nil {
+			t.Fatal(err)
+		}
+		b := buf.String()
+		i, idat := strings.Index(b, chunk), strings.Index(b, "IDAT")
+		switch {
+		case p == owm.AttributionOff && i >= 0,
+			p == owm.AttributionHeader && (i < 0 || i > idat),
+			p == owm.AttributionFooter && (i < 0 || i < idat):
+			t.Errorf("placement %d: unexpected chunk position %d, IDAT at %d", p, i, idat)
+		}
+		if _, err := png.Decode(&buf); err != nil {
+			t.Errorf("placement %d: %v", p, err)
+		}
+	}
+}
+
+func TestInvalid(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := PNG(&buf, nil); err != errNoValues {
+		t.Errorf("Expected %v, got %v", errNoValues, err)
+	}
+	if err := SVG(&buf, [][]float64{{math.NaN()}}); err != errNoValues {
+		t.Errorf("Expected %v, got %v", errNoValues, err)
+	}
+	for _, o := range []Option{nil, WithCellSize(0), WithRange(1, 1), WithAttribution(-1)} {
+		if err := PNG(&buf, values, o); err != errInvalidOption {
+			t.Errorf("Expected %v, got %v", errInvalidOption, err)
+		}
+	}
+}
@@ -15,10 +15,12 @@
 package openweathermap
 
 import (
-	"encoding/json"
+	"context"
 	"fmt"
 	"net/url"
+	"strconv"
 	"strings"
+	"time"
 )
 
 // HistoricalParameters struct holds the (optional) fields to be
@@ -29,16 +31,19 @@ type HistoricalParameters struct {
 	Cnt   int   // Amount of returned data (one per hour, can be used instead of Data end)
 }
 
-// Rain struct contains 3 hour data
+// Rain struct contains the rain volume, in mm, of the last hour and the
+// last 3 hours, from the "1h" and "3h" keys.  Responses carry either or
+// both, and leave the object out when it's dry.
 type Rain struct {
 	OneH   float64 `json:"1h,omitempty"`
-	ThreeH float64 `json:"3h"`
+	ThreeH float64 `json:"3h,omitempty"`
 }
 
-// Snow struct contains 3 hour data
+// Snow struct contains the snow volume, in mm, of the last hour and the
+// last 3 hours, from the "1h" and "3h" keys.
 type Snow struct {
 	OneH   float64 `json:"1h,omitempty"`
-	ThreeH float64 `json:"3h"`
+	ThreeH float64 `json:"3h,omitempty"`
 }
 
 // WeatherHistory struct contains aggregate fields from the above
@@ -49,14 +54,16 @@ type WeatherHistory struct {
 	Clouds  Clouds    `json:"clouds"`
 	Weather []Weather `json:"weather"`
 	Rain    Rain      `json:"rain"`
-	Dt      int       `json:"dt"`
+	Snow    Snow      `json:"snow"`
+	Dt      Timestamp `json:"dt"`
 }
 
 // HistoricalWeatherData struct is where the JSON is unmarshaled to
 // when receiving data for a historical request.
 type HistoricalWeatherData struct {
 	Message  string           `json:"message"`
-	Cod      int              `json:"cod"`
+	Cod      string           `json:"cod"`
+	CityID   int              `json:"city_id"`
 	CityData int              `json:"city_data"`
 	CalcTime float64          `json:"calctime"`
 	Cnt      int              `json:"cnt"`
@@ -93,13 +100,13 @@ func NewHistorical(unit, key string, options ...Option) (*HistoricalWeatherData,
 
 // HistoryByName will return the history for the provided location
 func (h *HistoricalWeatherData) HistoryByName(location string) error {
-	response, err := h.client.Get(fmt.Sprintf(fmt.Sprintf(historyURL, "city?appid=%s&q=%s"), h.Key, url.QueryEscape(location)))
+	response, err := h.client.Get(fmt.Sprintf(h.dataURL("history/city")+"?appid=%s&q=%s", h.Key, url.QueryEscape(location)))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if err = json.NewDecoder(response.Body).Decode(&h); err != nil {
+	if err = h.decode(response, &h); err != nil {
 		return err
 	}
 
@@ -109,24 +116,22 @@ func (h *HistoricalWeatherData) HistoryByName(location string) error {
 // HistoryByID will return the history for the provided location ID
 func (h *HistoricalWeatherData) HistoryByID(id int, hp ...*HistoricalParameters) error {
 	if len(hp) > 0 {
-		response, err := h.client.Get(fmt.Sprintf(fmt.Sprintf(historyURL, "city?appid=%s&id=%d&type=hour&start%d&end=%d&cnt=%d"), h.Key, id, hp[0].Start, hp[0].End, hp[0].Cnt))
+		response, err := h.client.Get(fmt.Sprintf(h.dataURL("history/city")+"?appid=%s&id=%d&type=hour&start=%d&end=%d&cnt=%d", h.Key, id, hp[0].Start, hp[0].End, hp[0].Cnt))
 		if err != nil {
 			return err
 		}
 		defer response.Body.Close()
 
-		if err = json.NewDecoder(response.Body).Decode(&h); err != nil {
-			return err
-		}
+		return h.decode(response, &h)
 	}
 
-	response, err := h.client.Get(fmt.Sprintf(fmt.Sprintf(historyURL, "city?appid=%s&id=%d"), h.Key, id))
+	response, err := h.client.Get(fmt.Sprintf(h.dataURL("history/city")+"?appid=%s&id=%d", h.Key, id))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if err = json.NewDecoder(response.Body).Decode(&h); err != nil {
+	if err = h.decode(response, &h); err != nil {
 		return err
 	}
 
@@ -135,15 +140,157 @@ func (h *HistoricalWeatherData) HistoryByID(id int, hp ...*HistoricalParameters)
 
 // HistoryByCoord will return the history for the provided coordinates
 func (h *HistoricalWeatherData) HistoryByCoord(location *Coordinates, hp *HistoricalParameters) error {
-	response, err := h.client.Get(fmt.Sprintf(fmt.Sprintf(historyURL, "appid=%s&lat=%f&lon=%f&start=%d&end=%d"), h.Key, location.Latitude, location.Longitude, hp.Start, hp.End))
+	response, err := h.client.Get(fmt.Sprintf(h.dataURL("history/city")+"?appid=%s&lat=%f&lon=%f&type=hour&start=%d&end=%d", h.Key, location.Latitude, location.Longitude, hp.Start, hp.End))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if err = json.NewDecoder(response.Body).Decode(&h); err != nil {
+	if err = h.decode(response, &h); err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// HistoryQuery selects the hours of a history request.  Start is
+// required; the hours end at End or after Cnt hours, whichever is set.
+type HistoryQuery struct {
+	Start time.Time
+	End   time.Time
+	Cnt   int
+}
+
+// history requests the hourly history selected by q for the given
+// location parameters.
+func (c *Client) history(ctx context.Context, params url.Values, q HistoryQuery) (*HistoricalWeatherData, error) {
+	if q.Start.IsZero() || (!q.End.IsZero() && q.End.Before(q.Start)) {
+		return nil, errInvalidTimeRange
+	}
+	if q.Cnt < 0 {
+		return nil, errForecastCount
+	}
+	params.Set("type", "hour")
+	params.Set("start", strconv.FormatInt(q.Start.Unix(), 10))
+	if !q.End.IsZero() {
+		params.Set("end", strconv.FormatInt(q.End.Unix(), 10))
+	}
+	if q.Cnt > 0 {
+		params.Set("cnt", strconv.Itoa(q.Cnt))
+	}
+
+	h := &HistoricalWeatherData{Unit: c.unit}
+	if err := c.get(ctx, c.dataURL("history/city")+"?"+c.query(params).Encode(), h); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// HistoryByName returns the hourly history selected by q for the
+// provided location name.  The history API requires a subscription to
+// it.
+func (c *Client) HistoryByName(ctx context.Context, location string, q HistoryQuery) (*HistoricalWeatherData, error) {
+	return c.history(ctx, url.Values{"q": {location}}, q)
+}
+
+// HistoryByCoordinates returns the hourly history selected by q for the
+// provided location coordinates.
+func (c *Client) HistoryByCoordinates(ctx context.Context, location *Coordinates, q HistoryQuery) (*HistoricalWeatherData, error) {
+	return c.history(ctx, coordinateParams(location), q)
+}
+
+// HistoryByID returns the hourly history selected by q for the provided
+// location ID.
+func (c *Client) HistoryByID(ctx context.Context, id int, q HistoryQuery) (*HistoricalWeatherData, error) {
+	return c.history(ctx, url.Values{"id": {strconv.Itoa(id)}}, q)
+}
+
+// AccumulatedPrecipitationDay holds the precipitation accumulated over
+// one day, in mm.  Count is the number of measurements it adds up.
+type AccumulatedPrecipitationDay struct {
+	Date  string  `json:"date"`
+	Rain  float64 `json:"rain"`
+	Count int     `json:"count"`
+}
+
+// AccumulatedPrecipitation holds the precipitation accumulated per day
+// over a period.
+type AccumulatedPrecipitation struct {
+	Days []AccumulatedPrecipitationDay
+}
+
+// Total returns the precipitation accumulated over the whole period, in
+// mm.
+func (a *AccumulatedPrecipitation) Total() float64 {
+	var total float64
+	for _, d := range a.Days {
+		total += d.Rain
+	}
+	return total
+}
+
+// accumulated requests the accumulated parameter endpoint path for the
+// given location coordinates and period, and decodes the daily values
+// into v.
+func (c *Client) accumulated(ctx context.Context, path string, location *Coordinates, start, end time.Time, threshold *float64, v interface{}) error {
+	if start.IsZero() || !end.After(start) {
+		return errInvalidTimeRange
+	}
+	params := coordinateParams(location)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+	if threshold != nil {
+		params.Set("threshold", strconv.FormatFloat(*threshold, 'f', -1, 64))
+	}
+	return c.get(ctx, c.dataURL("history/"+path)+"?"+c.query(params).Encode(), v)
+}
+
+// AccumulatedPrecipitation returns the precipitation accumulated per
+// day from start to end for the provided location coordinates.
+// Measurements below threshold mm are left out; nil counts them all.
+// Float returns a pointer to set it.  The history API requires a
+// subscription to it.
+func (c *Client) AccumulatedPrecipitation(ctx context.Context, location *Coordinates, start, end time.Time, threshold *float64) (*AccumulatedPrecipitation, error) {
+	a := &AccumulatedPrecipitation{}
+	if err := c.accumulated(ctx, "accumulated_precipitation", location, start, end, threshold, &a.Days); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// AccumulatedTemperatureDay holds the temperatures accumulated over one
+// day.  Count is the number of measurements it adds up.
+type AccumulatedTemperatureDay struct {
+	Date  string  `json:"date"`
+	Temp  float64 `json:"temp"`
+	Count int     `json:"count"`
+}
+
+// AccumulatedTemperature holds the temperatures accumulated per day over
+// a period, e.g. to follow growing degree days through a season.
+type AccumulatedTemperature struct {
+	Days []AccumulatedTemperatureDay
+	Unit string
+}
+
+// Total returns the temperatures accumulated over the whole period.
+func (a *AccumulatedTemperature) Total() float64 {
+	var total float64
+	for _, d := range a.Days {
+		total += d.Temp
+	}
+	return total
+}
+
+// AccumulatedTemperature returns the temperatures accumulated per day
+// from start to end for the provided location coordinates.
+// Measurements below threshold, in the units of the Client, are left
+// out; nil counts them all, while Float(0) sets a threshold of 0°.  The
+// history API requires a subscription to it.
+func (c *Client) AccumulatedTemperature(ctx context.Context, location *Coordinates, start, end time.Time, threshold *float64) (*AccumulatedTemperature, error) {
+	a := &AccumulatedTemperature{Unit: c.unit}
+	if err := c.accumulated(ctx, "accumulated_temperature", location, start, end, threshold, &a.Days); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
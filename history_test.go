@@ -15,6 +15,9 @@
 package openweathermap
 
 import (
+	"context"
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"os"
 	"reflect"
@@ -157,3 +160,147 @@ func TestHistoryByCoord(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestClientHistory(t *testing.T) {
+	t.Parallel()
+
+	var queries []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/history/city" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		queries = append(queries, r.URL.RawQuery)
+		fmt.Fprint(w, `{"message":"","cod":"200","city_id":5344157,"calctime":0.04,"cnt":1,"list":[{"dt":1461588000,"main":{"temp":12.5}}]}`)
+	})
+
+	ctx := context.Background()
+	start := time.Unix(1461588510, 0)
+	h, err := c.HistoryByID(ctx, 5344157, HistoryQuery{Start: start, End: start.Add(10 * time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h.List) != 1 || h.List[0].Main.Temp != 12.5 || h.Unit != "metric" {
+		t.Errorf("unexpected history %+v", h)
+	}
+	if _, err := c.HistoryByCoordinates(ctx, &Coordinates{Latitude: 33.45, Longitude: -112.07}, HistoryQuery{Start: start, Cnt: 3}); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"appid=" + testKey + "&end=1461624510&id=5344157&lang=EN&start=1461588510&type=hour&units=metric",
+		"appid=" + testKey + "&cnt=3&lang=EN&lat=33.45&lon=-112.07&start=1461588510&type=hour&units=metric",
+	}
+	if !reflect.DeepEqual(queries, want) {
+		t.Errorf("Expected queries %v, got %v", want, queries)
+	}
+
+	if _, err := c.HistoryByName(ctx, "Vancouver", HistoryQuery{Start: start, End: start.Add(-time.Hour)}); err != errInvalidTimeRange {
+		t.Errorf("Expected %v, got %v", errInvalidTimeRange, err)
+	}
+	if _, err := c.HistoryByName(ctx, "Vancouver", HistoryQuery{}); err != errInvalidTimeRange {
+		t.Errorf("Expected %v, got %v", errInvalidTimeRange, err)
+	}
+}
+
+func TestClientAccumulatedPrecipitation(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/history/accumulated_precipitation" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		want := "appid=" + testKey + "&end=1586563200&lang=EN&lat=52.1&lon=5.1&start=1586304000&threshold=0.5&units=metric"
+		if r.URL.RawQuery != want {
+			t.Errorf("Expected query %s, got %s", want, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[{"date":"2020-04-08","rain":1.5,"count":24},{"date":"2020-04-09","rain":0,"count":24},{"date":"2020-04-10","rain":4.25,"count":24}]`)
+	})
+
+	ctx := context.Background()
+	start := time.Date(2020, time.April, 8, 0, 0, 0, 0, time.UTC)
+	loc := &Coordinates{Latitude: 52.1, Longitude: 5.1}
+	a, err := c.AccumulatedPrecipitation(ctx, loc, start, start.Add(72*time.Hour), Float(0.5))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Days) != 3 || a.Days[2].Date != "2020-04-10" || a.Total() != 5.75 {
+		t.Errorf("unexpected result %+v", a)
+	}
+
+	if _, err := c.AccumulatedPrecipitation(ctx, loc, start, start, nil); err != errInvalidTimeRange {
+		t.Errorf("Expected %v, got %v", errInvalidTimeRange, err)
+	}
+}
+
+func TestClientAccumulatedTemperature(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/2.5/history/accumulated_temperature" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if r.URL.Query().Get("threshold") != "10" || r.URL.Query().Get("start") != "1588291200" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[{"date":"2020-05-01","temp":290.5,"count":24},{"date":"2020-05-02","temp":301,"count":24}]`)
+	})
+
+	ctx := context.Background()
+	start := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
+	loc := &Coordinates{Latitude: 52.1, Longitude: 5.1}
+	a, err := c.AccumulatedTemperature(ctx, loc, start, start.Add(48*time.Hour), Float(10))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Days) != 2 || a.Total() != 591.5 || a.Unit != "metric" {
+		t.Errorf("unexpected result %+v", a)
+	}
+
+	if _, err := c.AccumulatedTemperature(ctx, loc, time.Time{}, start, nil); err != errInvalidTimeRange {
+		t.Errorf("Expected %v, got %v", errInvalidTimeRange, err)
+	}
+}
+
+func TestClientAccumulatedThreshold(t *testing.T) {
+	t.Parallel()
+
+	var got []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		got = append(got, fmt.Sprint(q["threshold"]))
+		fmt.Fprint(w, `[]`)
+	})
+
+	ctx := context.Background()
+	start := time.Date(2020, time.May, 1, 0, 0, 0, 0, time.UTC)
+	loc := &Coordinates{Latitude: 52.1, Longitude: 5.1}
+	if _, err := c.AccumulatedTemperature(ctx, loc, start, start.Add(24*time.Hour), Float(0)); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.AccumulatedTemperature(ctx, loc, start, start.Add(24*time.Hour), nil); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"[0]", "[]"}; fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected thresholds %v, got %v", want, got)
+	}
+}
+
+func TestPrecipitationVolumes(t *testing.T) {
+	t.Parallel()
+
+	var h WeatherHistory
+	if err := json.Unmarshal([]byte(`{"rain":{"1h":0.5,"3h":1.7},"snow":{"3h":0.2}}`), &h); err != nil {
+		t.Fatal(err)
+	}
+	if h.Rain.OneH != 0.5 || h.Rain.ThreeH != 1.7 || h.Snow.OneH != 0 || h.Snow.ThreeH != 0.2 {
+		t.Errorf("unexpected volumes %+v %+v", h.Rain, h.Snow)
+	}
+
+	b, err := json.Marshal(h.Snow)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"3h":0.2}` {
+		t.Errorf("Expected only the 3h volume, got %s", b)
+	}
+}
@@ -0,0 +1,61 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+)
+
+// maxHourlyCount is the number of hours covered by the hourly forecast.
+const maxHourlyCount = 96
+
+// forecastHourly requests the hourly forecast for cnt hours, at most 96,
+// for the given location parameters.
+func (c *Client) forecastHourly(ctx context.Context, params url.Values, cnt int) (*Forecast5WeatherData, error) {
+	if c.xml {
+		return nil, errXMLUnsupported
+	}
+	if cnt < 1 || cnt > maxHourlyCount {
+		return nil, errForecastCount
+	}
+	params.Set("cnt", strconv.Itoa(cnt))
+
+	f := &Forecast5WeatherData{}
+	if err := c.get(ctx, c.dataURL("forecast/hourly")+"?"+c.query(params).Encode(), f); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// ForecastHourlyByName returns the hourly forecast for the next cnt
+// hours, 1 to 96, for the provided location name.  The hourly forecast
+// is served from the pro host and requires a paid subscription.  The
+// entries have the same layout as the 5 day forecast.
+func (c *Client) ForecastHourlyByName(ctx context.Context, location string, cnt int) (*Forecast5WeatherData, error) {
+	return c.forecastHourly(ctx, url.Values{"q": {location}}, cnt)
+}
+
+// ForecastHourlyByCoordinates returns the hourly forecast for the next
+// cnt hours, 1 to 96, for the provided location coordinates.
+func (c *Client) ForecastHourlyByCoordinates(ctx context.Context, location *Coordinates, cnt int) (*Forecast5WeatherData, error) {
+	return c.forecastHourly(ctx, coordinateParams(location), cnt)
+}
+
+// ForecastHourlyByID returns the hourly forecast for the next cnt hours,
+// 1 to 96, for the provided location ID.
+func (c *Client) ForecastHourlyByID(ctx context.Context, id, cnt int) (*Forecast5WeatherData, error) {
+	return c.forecastHourly(ctx, url.Values{"id": {strconv.Itoa(id)}}, cnt)
+}
@@ -0,0 +1,55 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestClientForecastHourly(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Host != "pro.openweathermap.org" || r.URL.Path != "/data/2.5/forecast/hourly" {
+			t.Errorf("unexpected url %s", r.URL)
+		}
+		if q := r.URL.Query(); q.Get("cnt") != "2" || q.Get("id") != "2643743" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"cod":"200","message":0,"cnt":2,"list":[
+{"dt":1661875200,"main":{"temp":18.4,"humidity":70},"weather":[{"id":500,"main":"Rain"}],"rain":{"1h":0.28},"pop":0.3,"dt_txt":"2022-08-30 16:00:00"},
+{"dt":1661878800,"main":{"temp":17.9,"humidity":73},"weather":[{"id":804,"main":"Clouds"}],"pop":0.1,"dt_txt":"2022-08-30 17:00:00"}],
+"city":{"id":2643743,"name":"London","coord":{"lat":51.5085,"lon":-0.1257},"country":"GB"}}`)
+	})
+
+	f, err := c.ForecastHourlyByID(context.Background(), 2643743, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.City.Name != "London" || len(f.List) != 2 || f.List[0].Rain.OneH != 0.28 || f.List[0].Pop != 0.3 || f.List[1].DtTxt.Hour() != 17 {
+		t.Errorf("unexpected result %+v", f)
+	}
+	if err := f.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	for _, cnt := range []int{0, 97} {
+		if _, err := c.ForecastHourlyByName(context.Background(), "London", cnt); err != errForecastCount {
+			t.Errorf("Expected %v for cnt %d, got %v", errForecastCount, cnt, err)
+		}
+	}
+}
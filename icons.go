@@ -0,0 +1,266 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"sync"
+)
+
+var errInvalidIcon = errors.New("invalid icon code or size")
+var errNoIconCache = errors.New("no icon cache directory set")
+
+// iconCodePattern matches the icon codes of Weather.Icon, e.g. "10n".
+var iconCodePattern = regexp.MustCompile(`^[0-9]{2}[dn]$`)
+
+// IconSize is the scale of a condition icon, relative to the standard
+// 50 pixel icon.
+type IconSize int
+
+// Icon sizes served by OWM.
+const (
+	IconSize1x IconSize = 1
+	IconSize2x IconSize = 2
+	IconSize4x IconSize = 4
+)
+
+// iconFileName returns the file name of the icon with the code icon at
+// size, e.g. "10n@2x.png".
+func iconFileName(icon string, size IconSize) (string, error) {
+	if !iconCodePattern.MatchString(icon) {
+		return "", errInvalidIcon
+	}
+	switch size {
+	case IconSize1x:
+		return icon + ".png", nil
+	case IconSize2x, IconSize4x:
+		return fmt.Sprintf("%s@%dx.png", icon, size), nil
+	}
+	return "", errInvalidIcon
+}
+
+// IconURL returns the URL of the icon with the code icon, e.g. "10n" as
+// found in Weather.Icon, at size.
+func IconURL(icon string, size IconSize) (string, error) {
+	name, err := iconFileName(icon, size)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf(iconURL, name), nil
+}
+
+// IconBytes returns the PNG of the icon with the code icon at size.
+// With WithIconCache, icons are read from and saved to the cache
+// directory.
+func (c *Client) IconBytes(ctx context.Context, icon string, size IconSize) ([]byte, error) {
+	name, err := iconFileName(icon, size)
+	if err != nil {
+		return nil, err
+	}
+	if c.iconDir == "" {
+		return c.fetchIcon(ctx, name)
+	}
+	b, _, err := c.cachedIcon(ctx, c.iconDir, name)
+	return b, err
+}
+
+// Icon returns the decoded icon with the code icon at size, see
+// IconBytes.
+func (c *Client) Icon(ctx context.Context, icon string, size IconSize) (image.Image, error) {
+	b, err := c.IconBytes(ctx, icon, size)
+	if err != nil {
+		return nil, err
+	}
+	return png.Decode(bytes.NewReader(b))
+}
+
+// writeIcon saves the icon b as name in dir.  It's written to a
+// temporary file first, so concurrent readers never see a partial icon.
+func writeIcon(dir, name string, b []byte) error {
+	f, err := ioutil.TempFile(dir, "."+name)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		os.Remove(f.Name())
+		return err
+	}
+	if err := f.Close(); err != nil {
+		os.Remove(f.Name())
+		return err
+	}
+	return os.Rename(f.Name(), filepath.Join(dir, name))
+}
+
+// knownIcon reports whether name is the file name of an icon of
+// IconList, e.g. "01d.png".
+func knownIcon(name string) bool {
+	for _, i := range IconList {
+		if name == i.Day || name == i.Night {
+			return true
+		}
+	}
+	return false
+}
+
+// retrieveIcon stores the icon iconFile in destination, downloading it
+// with the http client of s unless it's already there.  It returns the
+// number of bytes downloaded.
+func retrieveIcon(s *Settings, destination, iconFile string) (int64, error) {
+	b, fetched, err := s.cachedIcon(context.Background(), destination, iconFile)
+	if err != nil || !fetched {
+		return 0, err
+	}
+	return int64(len(b)), nil
+}
+
+// fetchIcon downloads the icon file name, e.g. "10d@2x.png", with the
+// http client of s.  Only complete 200 responses are returned.
+func (s *Settings) fetchIcon(ctx context.Context, name string) ([]byte, error) {
+	req, err := http.NewRequest(http.MethodGet, fmt.Sprintf(iconURL, name), nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	if response.StatusCode != http.StatusOK {
+		return nil, &APIError{COD: strconv.Itoa(response.StatusCode), Message: http.StatusText(response.StatusCode)}
+	}
+	return s.readBody(response)
+}
+
+// cachedIcon returns the icon file name from dir, downloading it and
+// storing it there first if it's missing.  fetched reports whether it
+// was downloaded.
+func (s *Settings) cachedIcon(ctx context.Context, dir, name string) (b []byte, fetched bool, err error) {
+	if b, err := ioutil.ReadFile(filepath.Join(dir, name)); err == nil {
+		return b, false, nil
+	}
+	if b, err = s.fetchIcon(ctx, name); err != nil {
+		return nil, false, err
+	}
+	if err := writeIcon(dir, name, b); err != nil {
+		return nil, false, err
+	}
+	return b, true, nil
+}
+
+// CacheIcons downloads the full standard icon set listed in IconList
+// into destination so it can be served without access to
+// openweathermap.org.  Icons already present are left untouched.
+func CacheIcons(destination string) error {
+	return preloadIcons(context.Background(), NewSettings(), destination)
+}
+
+// PreloadIcons downloads the icons of IconList missing from the cache
+// directory set with WithIconCache.  Icons are otherwise cached on first
+// use by IconBytes, so this lets a server fill the cache when it chooses,
+// e.g. at start up, bounded by ctx.
+func (c *Client) PreloadIcons(ctx context.Context) error {
+	if c.iconDir == "" {
+		return errNoIconCache
+	}
+	return preloadIcons(ctx, c.Settings, c.iconDir)
+}
+
+// preloadIcons stores the icons of IconList missing from dir, downloading
+// them with the http client of s.
+func preloadIcons(ctx context.Context, s *Settings, dir string) error {
+	for _, i := range IconList {
+		for _, f := range []string{i.Day, i.Night} {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if _, _, err := s.cachedIcon(ctx, dir, f); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// IconHandler returns an http.Handler serving condition icons out of
+// the directory dir.  The icon file name is taken from the last element
+// of the request path, so the handler can be mounted under any prefix.
+// Icons of IconList missing from dir are retrieved from
+// openweathermap.org once and cached there, so UIs don't have to hotlink
+// the OWM assets.  Other names are not found.
+func IconHandler(dir string) http.Handler {
+	return &iconHandler{dir: dir, s: NewSettings()}
+}
+
+type iconHandler struct {
+	dir string
+	s   *Settings
+
+	mu sync.Mutex
+	// locks holds a lock per icon file name, so an icon is retrieved
+	// once while requests for other icons are served.
+	locks map[string]*sync.Mutex
+}
+
+// lock returns the lock of the icon file name.
+func (h *iconHandler) lock(name string) *sync.Mutex {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	if h.locks == nil {
+		h.locks = map[string]*sync.Mutex{}
+	}
+	l, ok := h.locks[name]
+	if !ok {
+		l = &sync.Mutex{}
+		h.locks[name] = l
+	}
+	return l
+}
+
+// ServeHTTP implements http.Handler.
+func (h *iconHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	name := path.Base(r.URL.Path)
+	if !knownIcon(name) {
+		http.NotFound(w, r)
+		return
+	}
+
+	file := filepath.Join(h.dir, name)
+	if _, err := os.Stat(file); err != nil {
+		l := h.lock(name)
+		l.Lock()
+		_, err := retrieveIcon(h.s, h.dir, name)
+		l.Unlock()
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+	}
+
+	http.ServeFile(w, r, file)
+}
@@ -0,0 +1,263 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/png"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+)
+
+func TestIconHandler(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "owm-icons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	icon := []byte("\x89PNG fake icon")
+	if err := ioutil.WriteFile(filepath.Join(dir, "01d.png"), icon, 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	h := IconHandler(dir)
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/static/img/01d.png", nil))
+	if rec.Code != http.StatusOK {
+		t.Errorf("Expected status %d, got %d", http.StatusOK, rec.Code)
+	}
+	if rec.Body.String() != string(icon) {
+		t.Errorf("Expected cached icon to be served, got %q", rec.Body.String())
+	}
+
+	for _, p := range []string{"/static/img/../../etc/passwd", "/icons/", "/icons/1d.png", "/icons/99d.png"} {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", p, nil))
+		if rec.Code != http.StatusNotFound {
+			t.Errorf("Expected status %d for %s, got %d", http.StatusNotFound, p, rec.Code)
+		}
+	}
+}
+
+func TestRetrieveIconFailures(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "owm-icons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	status := http.StatusNotFound
+	s := NewSettings()
+	s.client = &http.Client{Transport: &handlerTransport{handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(status)
+		w.Write([]byte("icon"))
+	})}}
+
+	if _, err := retrieveIcon(s, dir, "10d.png"); err == nil {
+		t.Error("Expected an error for a 404 response")
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected nothing to be cached, got %d files", len(files))
+	}
+
+	status = http.StatusOK
+	if n, err := retrieveIcon(s, dir, "10d.png"); err != nil || n != 4 {
+		t.Errorf("Expected 4 bytes, got %d (%v)", n, err)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 1 || files[0].Name() != "10d.png" {
+		t.Errorf("Expected only the icon to be cached, got %v", files)
+	}
+	if n, err := retrieveIcon(s, dir, "10d@2x.png"); err != nil || n != 4 {
+		t.Errorf("Expected the icon outside IconList to be retrieved, got %d (%v)", n, err)
+	}
+}
+
+func TestIconHandlerConcurrentFetch(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "owm-icons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+	if err := ioutil.WriteFile(filepath.Join(dir, "01d.png"), []byte("cached"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	started, release := make(chan struct{}), make(chan struct{})
+	s := NewSettings()
+	s.client = &http.Client{Transport: &handlerTransport{handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		close(started)
+		<-release
+		w.Write([]byte("fetched"))
+	})}}
+	h := &iconHandler{dir: dir, s: s}
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() {
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, httptest.NewRequest("GET", "/icons/10d.png", nil))
+		done <- rec
+	}()
+	<-started
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/icons/01d.png", nil))
+	if rec.Body.String() != "cached" {
+		t.Errorf("Expected the cached icon while another is fetched, got %q", rec.Body.String())
+	}
+
+	close(release)
+	if rec := <-done; rec.Body.String() != "fetched" {
+		t.Errorf("Expected the fetched icon, got %q", rec.Body.String())
+	}
+}
+
+func TestIconURL(t *testing.T) {
+	t.Parallel()
+
+	tests := map[IconSize]string{
+		IconSize1x: "https://openweathermap.org/img/wn/10n.png",
+		IconSize2x: "https://openweathermap.org/img/wn/10n@2x.png",
+		IconSize4x: "https://openweathermap.org/img/wn/10n@4x.png",
+	}
+	for size, want := range tests {
+		if u, err := IconURL("10n", size); err != nil || u != want {
+			t.Errorf("IconURL(10n, %d): expected %s, got %s (%v)", size, want, u, err)
+		}
+	}
+	if _, err := IconURL("10n", 3); err != errInvalidIcon {
+		t.Errorf("Expected %v, got %v", errInvalidIcon, err)
+	}
+	if _, err := IconURL("../10n", IconSize1x); err != errInvalidIcon {
+		t.Errorf("Expected %v, got %v", errInvalidIcon, err)
+	}
+}
+
+func TestClientIcon(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "owm-icons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, 100, 100))); err != nil {
+		t.Fatal(err)
+	}
+	requests := 0
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Host != "openweathermap.org" || r.URL.Path != "/img/wn/01d@2x.png" {
+			t.Errorf("unexpected url %s", r.URL)
+		}
+		w.Write(buf.Bytes())
+	}, WithIconCache(dir))
+
+	ctx := context.Background()
+	for i := 0; i < 2; i++ {
+		img, err := c.Icon(ctx, "01d", IconSize2x)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if img.Bounds().Dx() != 100 {
+			t.Errorf("Expected a 100 pixel icon, got %v", img.Bounds())
+		}
+	}
+	if requests != 1 {
+		t.Errorf("Expected the cached icon to be used, got %d requests", requests)
+	}
+	if b, err := ioutil.ReadFile(filepath.Join(dir, "01d@2x.png")); err != nil || !bytes.Equal(b, buf.Bytes()) {
+		t.Errorf("Expected the icon to be cached, got %v", err)
+	}
+
+	if _, err := c.IconBytes(ctx, "01x", IconSize1x); err != errInvalidIcon {
+		t.Errorf("Expected %v, got %v", errInvalidIcon, err)
+	}
+}
+
+func TestClientIconError(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "owm-icons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+		w.Write([]byte("<html>bad gateway</html>"))
+	}, WithIconCache(dir))
+
+	_, err = c.IconBytes(context.Background(), "01d", IconSize1x)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.COD != "502" {
+		t.Errorf("Expected a 502 *APIError, got %v", err)
+	}
+	if files, _ := ioutil.ReadDir(dir); len(files) != 0 {
+		t.Errorf("Expected nothing to be cached, got %d files", len(files))
+	}
+}
+
+func TestClientPreloadIcons(t *testing.T) {
+	t.Parallel()
+
+	dir, err := ioutil.TempDir("", "owm-icons")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	var requests int32
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		atomic.AddInt32(&requests, 1)
+		w.Write([]byte("png"))
+	}, WithIconCache(dir))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.PreloadIcons(ctx); err != context.Canceled {
+		t.Errorf("Expected %v, got %v", context.Canceled, err)
+	}
+
+	for i := 0; i < 2; i++ {
+		if err := c.PreloadIcons(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+	}
+	files, _ := ioutil.ReadDir(dir)
+	if n := atomic.LoadInt32(&requests); n == 0 || int(n) != len(files) {
+		t.Errorf("Expected each icon to be downloaded once, got %d requests for %d files", n, len(files))
+	}
+
+	if err := newTestClient(t, nil).PreloadIcons(context.Background()); err != errNoIconCache {
+		t.Errorf("Expected %v, got %v", errNoIconCache, err)
+	}
+}
@@ -0,0 +1,228 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errNoAPIKeyAvailable = errors.New("no api key available")
+
+// KeyRotation selects how WithAPIKeys spreads requests over its keys.
+type KeyRotation int
+
+const (
+	// RoundRobin uses the keys in turn.
+	RoundRobin KeyRotation = iota
+	// LeastRecentlyUsed uses the key that has been idle the longest.
+	LeastRecentlyUsed
+)
+
+// How long a key is skipped after the API rejected it.  OWM rate limits
+// are per minute, while a 401 usually means the key is invalid, not yet
+// activated or out of its subscription.
+var (
+	rateLimitedKeyBackoff  = time.Minute
+	unauthorizedKeyBackoff = time.Hour
+)
+
+// invalidKeyMessage starts the message of the 401 OWM answers for a key
+// that is invalid or not yet activated, as opposed to a valid key used
+// for an endpoint outside its plan.
+const invalidKeyMessage = "invalid api key"
+
+// WithAPIKeys spreads requests over several API keys, replacing the key
+// given to the constructor.  A key answered with 429 Too Many Requests,
+// or with 401 Unauthorized because it is invalid, is skipped for a while
+// and the request is retried with the next available key.  A key refused
+// by an endpoint outside its plan is only skipped for that endpoint.
+func WithAPIKeys(rotation KeyRotation, keys ...string) Option {
+	return func(s *Settings) error {
+		if len(keys) == 0 || (rotation != RoundRobin && rotation != LeastRecentlyUsed) {
+			return errInvalidOption
+		}
+		p := &keyPool{rotation: rotation}
+		for _, k := range keys {
+			if err := ValidAPIKey(k); err != nil {
+				return err
+			}
+			p.keys = append(p.keys, &poolKey{key: k})
+		}
+		s.keys = p
+		return nil
+	}
+}
+
+type poolKey struct {
+	key          string
+	lastUsed     time.Time
+	blockedUntil time.Time
+	// endpointsBlockedUntil holds the blocks of single endpoints, by
+	// Endpoint label.
+	endpointsBlockedUntil map[string]time.Time
+}
+
+// keyPool hands out API keys according to its rotation.
+type keyPool struct {
+	mu       sync.Mutex
+	rotation KeyRotation
+	keys     []*poolKey
+	next     int
+}
+
+// pick returns the next key to use for endpoint, skipping blocked ones.
+// It returns nil when every key is blocked.
+func (p *keyPool) pick(endpoint string) *poolKey {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	var picked *poolKey
+	for i := range p.keys {
+		k := p.keys[(p.next+i)%len(p.keys)]
+		if now.Before(k.blockedUntil) || now.Before(k.endpointsBlockedUntil[endpoint]) {
+			continue
+		}
+		if p.rotation == RoundRobin {
+			picked = k
+			p.next = (p.next + i + 1) % len(p.keys)
+			break
+		}
+		if picked == nil || k.lastUsed.Before(picked.lastUsed) {
+			picked = k
+		}
+	}
+	if picked != nil {
+		picked.lastUsed = now
+	}
+	return picked
+}
+
+// block skips k for d, for every endpoint if endpoint is empty.
+func (p *keyPool) block(k *poolKey, endpoint string, d time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if endpoint == "" {
+		k.blockedUntil = time.Now().Add(d)
+		return
+	}
+	if k.endpointsBlockedUntil == nil {
+		k.endpointsBlockedUntil = map[string]time.Time{}
+	}
+	k.endpointsBlockedUntil[endpoint] = time.Now().Add(d)
+}
+
+// invalidKey reports whether the 401 response resp says the key itself
+// is invalid.  The start of the body is read and put back, so the
+// response can still be returned to the caller.
+func invalidKey(resp *http.Response) bool {
+	b, err := ioutil.ReadAll(io.LimitReader(resp.Body, 4096))
+	resp.Body = struct {
+		io.Reader
+		io.Closer
+	}{io.MultiReader(bytes.NewReader(b), resp.Body), resp.Body}
+	return err == nil && strings.Contains(strings.ToLower(string(b)), invalidKeyMessage)
+}
+
+// noKeyBackoffKey is the context key marking requests whose rejections
+// must not block the key, see withoutKeyBackoff.
+type noKeyBackoffKey struct{}
+
+// withoutKeyBackoff returns a copy of ctx for requests that expect to be
+// refused, such as probes.  Their 401 and 429 responses are returned
+// unchanged, without blocking the key or retrying with another.
+func withoutKeyBackoff(ctx context.Context) context.Context {
+	return context.WithValue(ctx, noKeyBackoffKey{}, true)
+}
+
+// attemptKey is the context key of the retry attempt of a request.
+type attemptKey struct{}
+
+// RetryAttempt returns how often the request with the given context was
+// sent before, e.g. with another key of WithAPIKeys after the API
+// rejected the first one.  It is 0 for the first attempt, so transports
+// wrapped by the Client can tell retries apart.
+func RetryAttempt(ctx context.Context) int {
+	attempt, _ := ctx.Value(attemptKey{}).(int)
+	return attempt
+}
+
+// keyRotator is an http.RoundTripper setting the appid parameter of
+// every request to a key from its pool.
+type keyRotator struct {
+	pool *keyPool
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *keyRotator) RoundTrip(req *http.Request) (*http.Response, error) {
+	ep := Endpoint(req.URL)
+	noBackoff, _ := req.Context().Value(noKeyBackoffKey{}).(bool)
+	k := r.pool.pick(ep)
+	if k == nil {
+		return nil, errNoAPIKeyAvailable
+	}
+
+	for attempt := 0; ; attempt++ {
+		u := *req.URL
+		q := u.Query()
+		q.Set("appid", k.key)
+		u.RawQuery = q.Encode()
+		keyed := req.Clone(context.WithValue(req.Context(), attemptKey{}, attempt))
+		keyed.URL = &u
+		if attempt > 0 && req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			keyed.Body = body
+		}
+
+		resp, err := r.next.RoundTrip(keyed)
+		if err != nil {
+			return nil, err
+		}
+
+		switch {
+		case noBackoff:
+			return resp, nil
+		case resp.StatusCode == http.StatusUnauthorized && invalidKey(resp):
+			r.pool.block(k, "", unauthorizedKeyBackoff)
+		case resp.StatusCode == http.StatusUnauthorized:
+			r.pool.block(k, ep, unauthorizedKeyBackoff)
+		case resp.StatusCode == http.StatusTooManyRequests:
+			r.pool.block(k, "", rateLimitedKeyBackoff)
+		default:
+			return resp, nil
+		}
+
+		// A request body can only be sent again if it can be recreated.
+		if req.Body != nil && req.GetBody == nil {
+			return resp, nil
+		}
+		if k = r.pool.pick(ep); k == nil {
+			return resp, nil
+		}
+		resp.Body.Close()
+	}
+}
@@ -0,0 +1,166 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+)
+
+var poolKeys = []string{
+	strings.Repeat("a", 32),
+	strings.Repeat("b", 32),
+	strings.Repeat("c", 32),
+}
+
+func TestWithAPIKeysRoundRobin(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var used []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		used = append(used, r.URL.Query().Get("appid"))
+		mu.Unlock()
+		fmt.Fprint(w, `{}`)
+	}, WithAPIKeys(RoundRobin, poolKeys...))
+
+	for i := 0; i < 4; i++ {
+		if _, err := c.CurrentByID(context.Background(), 1); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	want := []string{poolKeys[0], poolKeys[1], poolKeys[2], poolKeys[0]}
+	if !reflect.DeepEqual(used, want) {
+		t.Errorf("Expected keys %v, got %v", want, used)
+	}
+}
+
+func TestWithAPIKeysSkipsRejectedKeys(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var used []string
+	var attempts []int
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("appid")
+		mu.Lock()
+		used = append(used, key)
+		attempts = append(attempts, RetryAttempt(r.Context()))
+		mu.Unlock()
+		switch key {
+		case poolKeys[0]:
+			w.WriteHeader(http.StatusUnauthorized)
+		case poolKeys[1]:
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+		fmt.Fprint(w, `{"name":"Dublin"}`)
+	}, WithAPIKeys(LeastRecentlyUsed, poolKeys...))
+
+	for i := 0; i < 2; i++ {
+		w, err := c.CurrentByName(context.Background(), "Dublin")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if w.Name != "Dublin" {
+			t.Errorf("Expected Dublin, got %s", w.Name)
+		}
+	}
+
+	want := []string{poolKeys[0], poolKeys[1], poolKeys[2], poolKeys[2]}
+	if !reflect.DeepEqual(used, want) {
+		t.Errorf("Expected keys %v, got %v", want, used)
+	}
+	if want := []int{0, 1, 2, 0}; !reflect.DeepEqual(attempts, want) {
+		t.Errorf("Expected attempts %v, got %v", want, attempts)
+	}
+}
+
+func TestWithAPIKeysEndpointRefusal(t *testing.T) {
+	t.Parallel()
+
+	var mu sync.Mutex
+	var used []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		key := r.URL.Query().Get("appid")
+		mu.Lock()
+		used = append(used, Endpoint(r.URL)+" "+key[:1])
+		mu.Unlock()
+		switch {
+		case key == poolKeys[2]:
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"cod":401,"message":"Invalid API key. Please see https://openweathermap.org/faq#error401 for more info."}`)
+		case strings.Contains(r.URL.Path, "onecall"):
+			w.WriteHeader(http.StatusUnauthorized)
+			fmt.Fprint(w, `{"cod":401,"message":"Please note that using One Call 3.0 requires a separate subscription to the One Call by Call plan."}`)
+		default:
+			fmt.Fprint(w, `{"name":"Dublin"}`)
+		}
+	}, WithAPIKeys(RoundRobin, poolKeys[0], poolKeys[2]))
+
+	ctx := context.Background()
+	if _, err := c.CurrentByName(ctx, "Dublin"); err != nil {
+		t.Fatal(err)
+	}
+	_, err := c.OneCall(ctx, &Coordinates{Latitude: 53.3, Longitude: -6.3})
+	if apiErr, ok := err.(*APIError); !ok || apiErr.COD != "401" || !strings.Contains(apiErr.Message, "subscription") {
+		t.Errorf("Expected the plan refusal, got %v", err)
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := c.CurrentByName(ctx, "Dublin"); err != nil {
+			t.Fatalf("Expected the refused key to still serve other endpoints, got %v", err)
+		}
+	}
+
+	want := []string{"weather a", "onecall c", "onecall a", "weather a", "weather a"}
+	if !reflect.DeepEqual(used, want) {
+		t.Errorf("Expected requests %v, got %v", want, used)
+	}
+}
+
+func TestWithAPIKeysAllRejected(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+		fmt.Fprint(w, `{"cod":429,"message":"limit exceeded"}`)
+	}, WithAPIKeys(RoundRobin, poolKeys[:2]...))
+
+	_, err := c.CurrentByID(context.Background(), 1)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.COD != "429" {
+		t.Errorf("Expected the last 429 error, got %v", err)
+	}
+
+	if _, err := c.CurrentByID(context.Background(), 1); err == nil || !strings.Contains(err.Error(), errNoAPIKeyAvailable.Error()) {
+		t.Errorf("Expected %v, got %v", errNoAPIKeyAvailable, err)
+	}
+}
+
+func TestWithAPIKeysInvalid(t *testing.T) {
+	t.Parallel()
+
+	if _, err := NewClient("c", "en", testKey, WithAPIKeys(RoundRobin)); err != errInvalidOption {
+		t.Errorf("Expected %v, got %v", errInvalidOption, err)
+	}
+	if _, err := NewClient("c", "en", testKey, WithAPIKeys(RoundRobin, "short")); err == nil {
+		t.Error("Expected an invalid key error")
+	}
+}
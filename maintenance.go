@@ -0,0 +1,93 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"errors"
+	"net/http"
+	"time"
+)
+
+// ErrUpstreamMaintenance matches, with errors.Is, the *APIError of a
+// request OWM answered with a 502, 503 or 504 status, which it sends
+// during maintenance windows and outages.
+var ErrUpstreamMaintenance = errors.New("openweathermap: upstream maintenance")
+
+// maintenanceStaleTTL is how long cached samples are reused while OWM is
+// down for maintenance.
+const maintenanceStaleTTL = 2 * time.Hour
+
+// WithMaintenanceHandler sets fn to be called once with true when a
+// Client gets its first maintenance response, and once with false when
+// it gets a response again, instead of on every failed request.
+func WithMaintenanceHandler(fn func(active bool)) Option {
+	return func(s *Settings) error {
+		if fn == nil {
+			return errInvalidOption
+		}
+		s.maintenance = fn
+		return nil
+	}
+}
+
+// Is reports whether the error is a maintenance response, so
+// errors.Is(err, ErrUpstreamMaintenance) can be used.
+func (e *APIError) Is(target error) bool {
+	return target == ErrUpstreamMaintenance && maintenanceStatus(e.COD)
+}
+
+func maintenanceStatus(cod string) bool {
+	switch cod {
+	case "502", "503", "504":
+		return true
+	}
+	return false
+}
+
+// Maintenance returns when the current maintenance window started and
+// whether OWM is in one, as seen by the responses the Client got.
+func (c *Client) Maintenance() (since time.Time, active bool) {
+	c.outageMu.Lock()
+	defer c.outageMu.Unlock()
+	return c.outage, !c.outage.IsZero()
+}
+
+// observe starts or ends the maintenance window depending on the status
+// code of a response, and tells the maintenance handler when it does.
+func (c *Client) observe(code int) {
+	down := code == http.StatusBadGateway || code == http.StatusServiceUnavailable || code == http.StatusGatewayTimeout
+
+	c.outageMu.Lock()
+	changed := down == c.outage.IsZero()
+	if changed && down {
+		c.outage = time.Now()
+	} else if changed {
+		c.outage = time.Time{}
+	}
+	c.outageMu.Unlock()
+
+	if changed && c.maintenance != nil {
+		c.maintenance(down)
+	}
+}
+
+// cacheTTL returns how long cached samples are reused, which is extended
+// during maintenance windows.
+func (c *Client) cacheTTL() time.Duration {
+	if _, active := c.Maintenance(); active {
+		return maintenanceStaleTTL
+	}
+	return gridCacheTTL
+}
@@ -0,0 +1,105 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestClientMaintenance(t *testing.T) {
+	t.Parallel()
+
+	var down int32 = 1
+	var events []bool
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if atomic.LoadInt32(&down) == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"name":"Oslo"}`)
+	}, WithMaintenanceHandler(func(active bool) {
+		events = append(events, active)
+	}))
+
+	ctx := context.Background()
+	for i := 0; i < 3; i++ {
+		_, err := c.CurrentByName(ctx, "Oslo")
+		if !errors.Is(err, ErrUpstreamMaintenance) {
+			t.Errorf("Expected %v, got %v", ErrUpstreamMaintenance, err)
+		}
+	}
+	if since, active := c.Maintenance(); !active || since.IsZero() {
+		t.Error("Expected an active maintenance window")
+	}
+
+	atomic.StoreInt32(&down, 0)
+	if _, err := c.CurrentByName(ctx, "Oslo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, active := c.Maintenance(); active {
+		t.Error("Expected the maintenance window to be over")
+	}
+	if len(events) != 2 || !events[0] || events[1] {
+		t.Errorf("Expected one start and one end event, got %v", events)
+	}
+
+	if _, err := NewClient("c", "en", testKey, WithMaintenanceHandler(nil)); err != errInvalidOption {
+		t.Errorf("Expected %v, but got %v", errInvalidOption, err)
+	}
+}
+
+func TestAPIErrorIsMaintenance(t *testing.T) {
+	t.Parallel()
+
+	if errors.Is(&APIError{COD: "404", Message: "city not found"}, ErrUpstreamMaintenance) {
+		t.Error("Expected 404 not to be maintenance")
+	}
+	if !errors.Is(&APIError{COD: "502"}, ErrUpstreamMaintenance) {
+		t.Error("Expected 502 to be maintenance")
+	}
+}
+
+func TestSampleGridDuringMaintenance(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusBadGateway)
+	}, WithBatchInterval(0))
+
+	stale := Coordinates{Latitude: 10, Longitude: 20}
+	c.grid[stale] = gridEntry{weather: &CurrentWeatherData{Name: "stale"}, fetched: time.Now().Add(-time.Hour)}
+
+	box := BoundingBox{South: 10, West: 20, North: 10, East: 21}
+	g, err := c.SampleGrid(context.Background(), box, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if g.Cells[0][0].Weather != nil || !errors.Is(g.Cells[0][1].Err, ErrUpstreamMaintenance) {
+		t.Errorf("Expected the stale sample to be refetched before the outage, got %+v", g.Cells[0])
+	}
+
+	g, err = c.SampleGrid(context.Background(), box, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w := g.Cells[0][0].Weather; w == nil || w.Name != "stale" {
+		t.Errorf("Expected the stale sample during maintenance, got %+v", g.Cells[0][0])
+	}
+}
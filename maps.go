@@ -0,0 +1,179 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/draw"
+	"math"
+	"net/url"
+	"sync"
+)
+
+var errInvalidTile = errors.New("invalid map layer or tile coordinates")
+var errInvalidComposite = errors.New("invalid map layer, bounding box or zoom")
+var errCompositeTooLarge = errors.New("composite needs too many map tiles")
+
+// MaxCompositeTiles is the largest number of map tiles Composite
+// stitches together; every tile is a request.
+const MaxCompositeTiles = 16
+
+const (
+	tileSize    = 256
+	maxTileZoom = 18
+	maxTileLat  = 85.0511 // the Web Mercator map ends at this latitude
+)
+
+// MapLayer is a layer of the weather maps.
+type MapLayer string
+
+// Weather map layers.
+const (
+	LayerPrecipitation MapLayer = "precipitation_new"
+	LayerClouds        MapLayer = "clouds_new"
+	LayerTemperature   MapLayer = "temp_new"
+	LayerWind          MapLayer = "wind_new"
+	LayerPressure      MapLayer = "pressure_new"
+)
+
+// validLayer reports whether l is one of the weather map layers.
+func validLayer(l MapLayer) bool {
+	switch l {
+	case LayerPrecipitation, LayerClouds, LayerTemperature, LayerWind, LayerPressure:
+		return true
+	}
+	return false
+}
+
+// Maps gives access to the weather map tiles, which are 256x256 PNG
+// images in the Web Mercator projection used by most map libraries.
+type Maps struct {
+	c *Client
+}
+
+// Maps returns the Maps service of the Client.
+func (c *Client) Maps() *Maps {
+	return &Maps{c: c}
+}
+
+// TileURL returns the URL of tile x, y at zoom level z, 0 to 18, of
+// layer.  The URL holds the API key, so it should only be handed to
+// trusted clients.
+func (m *Maps) TileURL(layer MapLayer, z, x, y int) (string, error) {
+	if !validLayer(layer) || z < 0 || z > maxTileZoom {
+		return "", errInvalidTile
+	}
+	if n := 1 << uint(z); x < 0 || x >= n || y < 0 || y >= n {
+		return "", errInvalidTile
+	}
+	return fmt.Sprintf(tileURL, layer, z, x, y, url.Values{"appid": {m.c.key}}.Encode()), nil
+}
+
+// GetTile returns the image of tile x, y at zoom level z of layer.
+// Transparent pixels have no data for the layer.
+func (m *Maps) GetTile(ctx context.Context, layer MapLayer, z, x, y int) (image.Image, error) {
+	u, err := m.TileURL(layer, z, x, y)
+	if err != nil {
+		return nil, err
+	}
+	return m.c.getPNG(ctx, u)
+}
+
+// Composite returns the image of layer over box at zoom level zoom, 0 to
+// 18, stitched from the map tiles covering box, which are fetched
+// concurrently.  Transparent pixels have no data for the layer.
+func (m *Maps) Composite(ctx context.Context, box BoundingBox, zoom int, layer MapLayer) (*image.RGBA, error) {
+	px, ok := boxPixels(box, zoom)
+	if !ok || !validLayer(layer) {
+		return nil, errInvalidComposite
+	}
+	if tileCount(px) > MaxCompositeTiles {
+		return nil, errCompositeTooLarge
+	}
+	return stitchTiles(ctx, px, func(ctx context.Context, x, y int) (image.Image, error) {
+		return m.GetTile(ctx, layer, zoom, x, y)
+	})
+}
+
+// tilePixel returns the position of lat and lon in pixels on the Web
+// Mercator map of the world at zoom.
+func tilePixel(lat, lon float64, zoom int) (x, y float64) {
+	n := float64(int(tileSize) << uint(zoom))
+	r := lat * math.Pi / 180
+	x = (lon + 180) / 360 * n
+	y = (1 - math.Log(math.Tan(r)+1/math.Cos(r))/math.Pi) / 2 * n
+	return x, y
+}
+
+// boxPixels returns the pixels box covers on the map of the world at
+// zoom, or false if box or zoom is invalid.
+func boxPixels(box BoundingBox, zoom int) (image.Rectangle, bool) {
+	if zoom < 0 || zoom > maxTileZoom || box.South >= box.North || box.West >= box.East ||
+		box.South < -maxTileLat || box.North > maxTileLat || box.West < -180 || box.East > 180 {
+		return image.Rectangle{}, false
+	}
+	x0, y0 := tilePixel(box.North, box.West, zoom)
+	x1, y1 := tilePixel(box.South, box.East, zoom)
+	return image.Rect(int(math.Floor(x0)), int(math.Floor(y0)), int(math.Ceil(x1)), int(math.Ceil(y1))), true
+}
+
+// tileCount returns the number of map tiles covering px.
+func tileCount(px image.Rectangle) int {
+	return ((px.Max.X-1)/tileSize - px.Min.X/tileSize + 1) * ((px.Max.Y-1)/tileSize - px.Min.Y/tileSize + 1)
+}
+
+// stitchTiles returns the image of px, pixels of the map of the world,
+// drawn from the tiles covering it.  The tiles are fetched concurrently
+// with tile; the first error cancels the remaining requests.
+func stitchTiles(ctx context.Context, px image.Rectangle, tile func(ctx context.Context, x, y int) (image.Image, error)) (*image.RGBA, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		wg       sync.WaitGroup
+		mu       sync.Mutex
+		firstErr error
+	)
+	img := image.NewRGBA(image.Rect(0, 0, px.Dx(), px.Dy()))
+	for ty := px.Min.Y / tileSize; ty <= (px.Max.Y-1)/tileSize; ty++ {
+		for tx := px.Min.X / tileSize; tx <= (px.Max.X-1)/tileSize; tx++ {
+			wg.Add(1)
+			go func(tx, ty int) {
+				defer wg.Done()
+				t, err := tile(ctx, tx, ty)
+
+				mu.Lock()
+				defer mu.Unlock()
+				if err != nil {
+					if firstErr == nil {
+						firstErr = err
+						cancel()
+					}
+					return
+				}
+				r := image.Rect(tx*tileSize, ty*tileSize, (tx+1)*tileSize, (ty+1)*tileSize).Sub(px.Min)
+				draw.Draw(img, r, t, t.Bounds().Min, draw.Over)
+			}(tx, ty)
+		}
+	}
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	return img, nil
+}
@@ -0,0 +1,183 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"image"
+	"image/color"
+	"image/png"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestMapsTileURL(t *testing.T) {
+	t.Parallel()
+
+	m := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {}).Maps()
+
+	u, err := m.TileURL(LayerPrecipitation, 5, 16, 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "https://tile.openweathermap.org/map/precipitation_new/5/16/10.png?appid=" + testKey
+	if u != want {
+		t.Errorf("Expected %s, got %s", want, u)
+	}
+
+	tests := []struct {
+		layer   MapLayer
+		z, x, y int
+	}{
+		{"snow_new", 1, 0, 0},
+		{LayerWind, -1, 0, 0},
+		{LayerWind, 19, 0, 0},
+		{LayerClouds, 2, 4, 0},
+		{LayerTemperature, 2, 0, -1},
+	}
+	for _, tt := range tests {
+		if _, err := m.TileURL(tt.layer, tt.z, tt.x, tt.y); err != errInvalidTile {
+			t.Errorf("TileURL(%s, %d, %d, %d): expected %v, got %v", tt.layer, tt.z, tt.x, tt.y, errInvalidTile, err)
+		}
+	}
+}
+
+func TestMapsGetTile(t *testing.T) {
+	t.Parallel()
+
+	tile := image.NewNRGBA(image.Rect(0, 0, 256, 256))
+	tile.Set(3, 4, color.NRGBA{R: 0xff, A: 0x80})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, tile); err != nil {
+		t.Fatal(err)
+	}
+
+	m := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/map/clouds_new/3/4/2.png" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		w.Write(buf.Bytes())
+	}).Maps()
+
+	img, err := m.GetTile(context.Background(), LayerClouds, 3, 4, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != 256 {
+		t.Errorf("Expected a 256 pixel tile, got %v", img.Bounds())
+	}
+	if _, _, _, a := img.At(3, 4).RGBA(); a == 0 {
+		t.Error("Expected the drawn pixel to be opaque")
+	}
+
+	if _, err := m.GetTile(context.Background(), LayerClouds, 3, 8, 2); err != errInvalidTile {
+		t.Errorf("Expected %v, got %v", errInvalidTile, err)
+	}
+}
+
+func TestMapsGetTileError(t *testing.T) {
+	t.Parallel()
+
+	m := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnauthorized)
+		fmt.Fprint(w, `{"cod":401,"message":"Invalid API key"}`)
+	}).Maps()
+
+	_, err := m.GetTile(context.Background(), LayerWind, 0, 0, 0)
+	if apiErr, ok := err.(*APIError); !ok || apiErr.COD != "401" {
+		t.Errorf("Expected a 401 *APIError, got %v", err)
+	}
+}
+
+func TestTilePixel(t *testing.T) {
+	t.Parallel()
+
+	if x, y := tilePixel(0, 0, 0); !almostEqual(x, 128) || !almostEqual(y, 128) {
+		t.Errorf("Expected the center of the world tile, got %v, %v", x, y)
+	}
+	if x, y := tilePixel(maxTileLat, -180, 1); !almostEqual(x, 0) || math.Abs(y) > 0.1 {
+		t.Errorf("Expected the top left corner, got %v, %v", x, y)
+	}
+}
+
+func TestMapsComposite(t *testing.T) {
+	t.Parallel()
+
+	tile := image.NewNRGBA(image.Rect(0, 0, tileSize, tileSize))
+	tile.Set(0, 0, color.NRGBA{G: 0xff, A: 0xff})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, tile); err != nil {
+		t.Fatal(err)
+	}
+
+	var mu sync.Mutex
+	paths := map[string]bool{}
+	m := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths[r.URL.Path] = true
+		mu.Unlock()
+		w.Write(buf.Bytes())
+	}).Maps()
+
+	box := BoundingBox{South: 47, West: 5, North: 55, East: 15}
+	img, err := m.Composite(context.Background(), box, 5, LayerPrecipitation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	px, _ := boxPixels(box, 5)
+	if img.Bounds() != image.Rect(0, 0, px.Dx(), px.Dy()) {
+		t.Errorf("Expected the image to cover %v, got %v", px, img.Bounds())
+	}
+	if len(paths) != tileCount(px) {
+		t.Errorf("Expected %d tiles, got %v", tileCount(px), paths)
+	}
+	for p := range paths {
+		if !strings.HasPrefix(p, "/map/precipitation_new/5/") {
+			t.Errorf("unexpected path %s", p)
+		}
+	}
+	// The top left pixel of the next tile to the right is drawn.
+	x := (px.Min.X/tileSize+1)*tileSize - px.Min.X
+	if _, g, _, _ := img.At(x, (px.Min.Y/tileSize+1)*tileSize-px.Min.Y).RGBA(); g == 0 {
+		t.Error("Expected the tiles to be stitched together")
+	}
+}
+
+func TestMapsCompositeInvalid(t *testing.T) {
+	t.Parallel()
+
+	m := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}).Maps()
+	ctx := context.Background()
+	box := BoundingBox{South: 47, West: 5, North: 55, East: 15}
+
+	if _, err := m.Composite(ctx, box, 5, "snow"); err != errInvalidComposite {
+		t.Errorf("Expected %v, got %v", errInvalidComposite, err)
+	}
+	if _, err := m.Composite(ctx, BoundingBox{South: 55, West: 5, North: 47, East: 15}, 5, LayerWind); err != errInvalidComposite {
+		t.Errorf("Expected %v, got %v", errInvalidComposite, err)
+	}
+	if _, err := m.Composite(ctx, BoundingBox{South: -60, West: -170, North: 60, East: 170}, 8, LayerWind); err != errCompositeTooLarge {
+		t.Errorf("Expected %v, got %v", errCompositeTooLarge, err)
+	}
+	if _, err := m.Composite(ctx, box, 5, LayerWind); err == nil {
+		t.Error("Expected the failed tile requests to be returned")
+	}
+}
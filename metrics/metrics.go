@@ -0,0 +1,118 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package metrics provides optional Prometheus instrumentation for the
+// openweathermap package.  A Collector wraps the http.RoundTripper used
+// by the client and records request counts, error counts and latency
+// labeled by OWM endpoint.
+//
+//	c := metrics.NewCollector("myapp")
+//	prometheus.MustRegister(c)
+//
+//	hc := &http.Client{Transport: c.Transport(nil)}
+//	w, err := owm.NewCurrent("F", "EN", apiKey, owm.WithHttpClient(hc))
+package metrics
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	owm "github.com/briandowns/openweathermap"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Collector holds the metrics for the OWM traffic sent through its
+// Transport. It implements prometheus.Collector.
+type Collector struct {
+	requests *prometheus.CounterVec
+	errors   *prometheus.CounterVec
+	latency  *prometheus.HistogramVec
+}
+
+// NewCollector returns a new Collector pointer with its metrics placed
+// under the given namespace.
+func NewCollector(namespace string) *Collector {
+	return &Collector{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "owm",
+			Name:      "requests_total",
+			Help:      "Number of requests sent to the OpenWeatherMap API.",
+		}, []string{"endpoint", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: "owm",
+			Name:      "errors_total",
+			Help:      "Number of failed requests to the OpenWeatherMap API.",
+		}, []string{"endpoint"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: namespace,
+			Subsystem: "owm",
+			Name:      "request_duration_seconds",
+			Help:      "Latency of requests to the OpenWeatherMap API.",
+			Buckets:   prometheus.DefBuckets,
+		}, []string{"endpoint"}),
+	}
+}
+
+// Describe sends the descriptors of the collected metrics to ch.
+func (c *Collector) Describe(ch chan<- *prometheus.Desc) {
+	c.requests.Describe(ch)
+	c.errors.Describe(ch)
+	c.latency.Describe(ch)
+}
+
+// Collect sends the current metric values to ch.
+func (c *Collector) Collect(ch chan<- prometheus.Metric) {
+	c.requests.Collect(ch)
+	c.errors.Collect(ch)
+	c.latency.Collect(ch)
+}
+
+// Transport returns an http.RoundTripper that records metrics for every
+// request before handing it to next.  If next is nil, the shared
+// transport of the openweathermap package, owm.DefaultTransport, is used.
+func (c *Collector) Transport(next http.RoundTripper) http.RoundTripper {
+	if next == nil {
+		next = owm.DefaultTransport()
+	}
+	return &transport{collector: c, next: next}
+}
+
+type transport struct {
+	collector *Collector
+	next      http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ep := owm.Endpoint(req.URL)
+
+	start := time.Now()
+	resp, err := t.next.RoundTrip(req)
+	t.collector.latency.WithLabelValues(ep).Observe(time.Since(start).Seconds())
+
+	if err != nil {
+		t.collector.requests.WithLabelValues(ep, "").Inc()
+		t.collector.errors.WithLabelValues(ep).Inc()
+		return nil, err
+	}
+
+	t.collector.requests.WithLabelValues(ep, strconv.Itoa(resp.StatusCode)).Inc()
+	if resp.StatusCode >= http.StatusBadRequest {
+		t.collector.errors.WithLabelValues(ep).Inc()
+	}
+	return resp, nil
+}
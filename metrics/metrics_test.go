@@ -0,0 +1,76 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	c := NewCollector("test")
+	next := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		switch r.URL.Path {
+		case "/data/2.5/weather":
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusOK)
+			return rec.Result(), nil
+		case "/data/2.5/forecast":
+			rec := httptest.NewRecorder()
+			rec.WriteHeader(http.StatusUnauthorized)
+			return rec.Result(), nil
+		}
+		return nil, errors.New("connection refused")
+	})
+	hc := &http.Client{Transport: c.Transport(next)}
+
+	for _, u := range []string{
+		"http://api.openweathermap.org/data/2.5/weather?q=Philadelphia",
+		"http://api.openweathermap.org/data/2.5/weather?q=Newark",
+		"http://api.openweathermap.org/data/2.5/forecast?q=Helena",
+		"http://api.openweathermap.org/data/2.5/uvi?lat=1&lon=2",
+	} {
+		resp, err := hc.Get(u)
+		if err == nil {
+			resp.Body.Close()
+		}
+	}
+
+	if n := testutil.CollectAndCount(c); n != 8 {
+		t.Errorf("Expected 8 metrics, got %d", n)
+	}
+	if n := testutil.ToFloat64(c.requests.WithLabelValues("weather", "200")); n != 2 {
+		t.Errorf("Expected 2 weather requests, got %v", n)
+	}
+	if n := testutil.ToFloat64(c.errors.WithLabelValues("weather")); n != 0 {
+		t.Errorf("Expected 0 weather errors, got %v", n)
+	}
+	if n := testutil.ToFloat64(c.errors.WithLabelValues("forecast")); n != 1 {
+		t.Errorf("Expected 1 forecast error, got %v", n)
+	}
+	if n := testutil.ToFloat64(c.errors.WithLabelValues("uvi")); n != 1 {
+		t.Errorf("Expected 1 uvi error, got %v", n)
+	}
+}
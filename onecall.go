@@ -0,0 +1,376 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// OneCallBlock names a block of a One Call response that can be excluded
+// from it.
+type OneCallBlock string
+
+// One Call response blocks.
+const (
+	OneCallBlockCurrent  OneCallBlock = "current"
+	OneCallBlockMinutely OneCallBlock = "minutely"
+	OneCallBlockHourly   OneCallBlock = "hourly"
+	OneCallBlockDaily    OneCallBlock = "daily"
+	OneCallBlockAlerts   OneCallBlock = "alerts"
+)
+
+// OneCallCurrent holds the current conditions of a One Call response.
+type OneCallCurrent struct {
+	Dt         Timestamp `json:"dt"`
+	Sunrise    Timestamp `json:"sunrise"`
+	Sunset     Timestamp `json:"sunset"`
+	Temp       float64   `json:"temp"`
+	FeelsLike  float64   `json:"feels_like"`
+	Pressure   float64   `json:"pressure"`
+	Humidity   int       `json:"humidity"`
+	DewPoint   float64   `json:"dew_point"`
+	UVI        float64   `json:"uvi"`
+	Clouds     int       `json:"clouds"`
+	Visibility int       `json:"visibility"`
+	WindSpeed  float64   `json:"wind_speed"`
+	WindGust   float64   `json:"wind_gust"`
+	WindDeg    float64   `json:"wind_deg"`
+	Rain       Rain      `json:"rain"`
+	Snow       Snow      `json:"snow"`
+	Weather    []Weather `json:"weather"`
+}
+
+// OneCallMinutely holds the precipitation forecast for one minute.
+// Precipitation is an intensity, in mm/h.
+type OneCallMinutely struct {
+	Dt            time.Time
+	Precipitation float64
+}
+
+// minutelyJSON is a OneCallMinutely as the API encodes it.
+type minutelyJSON struct {
+	Dt            int64   `json:"dt"`
+	Precipitation float64 `json:"precipitation"`
+}
+
+// UnmarshalJSON decodes a minute as the API encodes it.
+func (m *OneCallMinutely) UnmarshalJSON(b []byte) error {
+	var raw minutelyJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*m = OneCallMinutely{Dt: time.Unix(raw.Dt, 0).UTC(), Precipitation: raw.Precipitation}
+	return nil
+}
+
+// MarshalJSON encodes the minute the way the API does.
+func (m OneCallMinutely) MarshalJSON() ([]byte, error) {
+	return json.Marshal(minutelyJSON{m.Dt.Unix(), m.Precipitation})
+}
+
+// OneCallHourly holds the forecast for one hour.
+type OneCallHourly struct {
+	Dt         Timestamp `json:"dt"`
+	Temp       float64   `json:"temp"`
+	FeelsLike  float64   `json:"feels_like"`
+	Pressure   float64   `json:"pressure"`
+	Humidity   int       `json:"humidity"`
+	DewPoint   float64   `json:"dew_point"`
+	UVI        float64   `json:"uvi"`
+	Clouds     int       `json:"clouds"`
+	Visibility int       `json:"visibility"`
+	WindSpeed  float64   `json:"wind_speed"`
+	WindGust   float64   `json:"wind_gust"`
+	WindDeg    float64   `json:"wind_deg"`
+	Pop        float64   `json:"pop"`
+	Rain       Rain      `json:"rain"`
+	Snow       Snow      `json:"snow"`
+	Weather    []Weather `json:"weather"`
+}
+
+// OneCallDaily holds the forecast for one day.
+type OneCallDaily struct {
+	Dt        Timestamp   `json:"dt"`
+	Sunrise   Timestamp   `json:"sunrise"`
+	Sunset    Timestamp   `json:"sunset"`
+	Moonrise  Timestamp   `json:"moonrise"`
+	Moonset   Timestamp   `json:"moonset"`
+	MoonPhase float64     `json:"moon_phase"`
+	Summary   string      `json:"summary"`
+	Temp      Temperature `json:"temp"`
+	FeelsLike Temperature `json:"feels_like"`
+	Pressure  float64     `json:"pressure"`
+	Humidity  int         `json:"humidity"`
+	DewPoint  float64     `json:"dew_point"`
+	WindSpeed float64     `json:"wind_speed"`
+	WindGust  float64     `json:"wind_gust"`
+	WindDeg   float64     `json:"wind_deg"`
+	Clouds    int         `json:"clouds"`
+	UVI       float64     `json:"uvi"`
+	Pop       float64     `json:"pop"`
+	Rain      float64     `json:"rain"`
+	Snow      float64     `json:"snow"`
+	Weather   []Weather   `json:"weather"`
+}
+
+// Alert is a national weather alert for a location.
+type Alert struct {
+	Sender      string
+	Event       string
+	Start       time.Time
+	End         time.Time
+	Description string
+	Tags        []string
+}
+
+// alertJSON is an Alert as the API encodes it.
+type alertJSON struct {
+	SenderName  string   `json:"sender_name"`
+	Event       string   `json:"event"`
+	Start       int64    `json:"start"`
+	End         int64    `json:"end"`
+	Description string   `json:"description"`
+	Tags        []string `json:"tags"`
+}
+
+// UnmarshalJSON decodes an alert as the API encodes it.
+func (a *Alert) UnmarshalJSON(b []byte) error {
+	var raw alertJSON
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	*a = Alert{
+		Sender:      raw.SenderName,
+		Event:       raw.Event,
+		Start:       time.Unix(raw.Start, 0).UTC(),
+		End:         time.Unix(raw.End, 0).UTC(),
+		Description: raw.Description,
+		Tags:        raw.Tags,
+	}
+	return nil
+}
+
+// MarshalJSON encodes the alert the way the API does.
+func (a Alert) MarshalJSON() ([]byte, error) {
+	return json.Marshal(alertJSON{a.Sender, a.Event, a.Start.Unix(), a.End.Unix(), a.Description, a.Tags})
+}
+
+// Active reports whether the alert is in effect at t.
+func (a *Alert) Active(t time.Time) bool {
+	return !t.Before(a.Start) && t.Before(a.End)
+}
+
+// OneCallData holds the current weather, minutely forecast for 1 hour,
+// hourly forecast for 48 hours, daily forecast for 8 days and alerts for
+// a location, as returned by the One Call API 3.0.
+type OneCallData struct {
+	Latitude       float64           `json:"lat"`
+	Longitude      float64           `json:"lon"`
+	Timezone       string            `json:"timezone"`
+	TimezoneOffset int               `json:"timezone_offset"`
+	Current        OneCallCurrent    `json:"current"`
+	Minutely       []OneCallMinutely `json:"minutely"`
+	Hourly         []OneCallHourly   `json:"hourly"`
+	Daily          []OneCallDaily    `json:"daily"`
+	Alerts         []Alert           `json:"alerts"`
+	Unit           string
+	Lang           string
+}
+
+// ActiveAlerts returns the alerts in effect at t.
+func (o *OneCallData) ActiveAlerts(t time.Time) []Alert {
+	var active []Alert
+	for i := range o.Alerts {
+		if o.Alerts[i].Active(t) {
+			active = append(active, o.Alerts[i])
+		}
+	}
+	return active
+}
+
+// NextPrecipitation returns the first minute of the minute forecast
+// with precipitation, or false if it's dry for the whole hour or the
+// minutely block wasn't requested.
+func (o *OneCallData) NextPrecipitation() (time.Time, bool) {
+	for _, m := range o.Minutely {
+		if m.Precipitation > 0 {
+			return m.Dt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// PrecipitationInNext returns the precipitation, in mm, forecast for
+// the first d of the minute forecast, which covers an hour.
+func (o *OneCallData) PrecipitationInNext(d time.Duration) float64 {
+	if len(o.Minutely) == 0 {
+		return 0
+	}
+	end := o.Minutely[0].Dt.Add(d)
+	var total float64
+	for _, m := range o.Minutely {
+		if !m.Dt.Before(end) {
+			break
+		}
+		total += m.Precipitation / 60
+	}
+	return total
+}
+
+// OneCallTimemachineData holds the weather at a point in time for a
+// location, as returned by the One Call timemachine endpoint.
+type OneCallTimemachineData struct {
+	Latitude       float64          `json:"lat"`
+	Longitude      float64          `json:"lon"`
+	Timezone       string           `json:"timezone"`
+	TimezoneOffset int              `json:"timezone_offset"`
+	Data           []OneCallCurrent `json:"data"`
+	Unit           string
+	Lang           string
+}
+
+// DaySummaryData holds the weather aggregated over one calendar day for
+// a location, as returned by the One Call day_summary endpoint.
+type DaySummaryData struct {
+	Latitude   float64 `json:"lat"`
+	Longitude  float64 `json:"lon"`
+	Tz         string  `json:"tz"`
+	Date       string  `json:"date"`
+	Units      string  `json:"units"`
+	CloudCover struct {
+		Afternoon float64 `json:"afternoon"`
+	} `json:"cloud_cover"`
+	Humidity struct {
+		Afternoon float64 `json:"afternoon"`
+	} `json:"humidity"`
+	Precipitation struct {
+		Total float64 `json:"total"`
+	} `json:"precipitation"`
+	Temperature struct {
+		Min       float64 `json:"min"`
+		Max       float64 `json:"max"`
+		Afternoon float64 `json:"afternoon"`
+		Night     float64 `json:"night"`
+		Evening   float64 `json:"evening"`
+		Morning   float64 `json:"morning"`
+	} `json:"temperature"`
+	Pressure struct {
+		Afternoon float64 `json:"afternoon"`
+	} `json:"pressure"`
+	Wind struct {
+		Max struct {
+			Speed     float64 `json:"speed"`
+			Direction float64 `json:"direction"`
+		} `json:"max"`
+	} `json:"wind"`
+	Unit string
+	Lang string
+}
+
+// OverviewData holds the human readable weather summary for a location,
+// as returned by the One Call overview endpoint.
+type OverviewData struct {
+	Latitude        float64 `json:"lat"`
+	Longitude       float64 `json:"lon"`
+	Tz              string  `json:"tz"`
+	Date            string  `json:"date"`
+	Units           string  `json:"units"`
+	WeatherOverview string  `json:"weather_overview"`
+	Unit            string
+	Lang            string
+}
+
+// OneCall returns the One Call data for the provided location
+// coordinates.  Blocks listed in exclude are left out of the response
+// and stay empty.  The One Call API 3.0 requires a separate subscription.
+func (c *Client) OneCall(ctx context.Context, location *Coordinates, exclude ...OneCallBlock) (*OneCallData, error) {
+	params := coordinateParams(location)
+	if len(exclude) > 0 {
+		blocks := make([]string, len(exclude))
+		for i, b := range exclude {
+			blocks[i] = string(b)
+		}
+		params.Set("exclude", strings.Join(blocks, ","))
+	}
+
+	o := &OneCallData{
+		Unit: c.unit,
+		Lang: c.lang,
+	}
+	if err := c.get(ctx, c.dataURL("onecall")+"?"+c.query(params).Encode(), o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// OneCallTimemachine returns the weather for the provided location
+// coordinates at time dt.  Data is available from 1st January 1979 up
+// to 4 days ahead.
+func (c *Client) OneCallTimemachine(ctx context.Context, location *Coordinates, dt time.Time) (*OneCallTimemachineData, error) {
+	params := coordinateParams(location)
+	params.Set("dt", strconv.FormatInt(dt.Unix(), 10))
+
+	o := &OneCallTimemachineData{
+		Unit: c.unit,
+		Lang: c.lang,
+	}
+	if err := c.get(ctx, c.dataURL("onecall/timemachine")+"?"+c.query(params).Encode(), o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
+
+// DaySummary returns the weather aggregated over the calendar day of
+// date for the provided location coordinates.  The day is taken in the
+// location of date, e.g. time.Date(2020, 3, 4, 0, 0, 0, 0, time.UTC) is
+// 4th March 2020 in UTC.
+func (c *Client) DaySummary(ctx context.Context, location *Coordinates, date time.Time) (*DaySummaryData, error) {
+	params := coordinateParams(location)
+	params.Set("date", date.Format("2006-01-02"))
+	params.Set("tz", date.Format("-07:00"))
+
+	d := &DaySummaryData{
+		Unit: c.unit,
+		Lang: c.lang,
+	}
+	if err := c.get(ctx, c.dataURL("onecall/day_summary")+"?"+c.query(params).Encode(), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Overview returns the textual weather summary for the provided location
+// coordinates, generated by OWM for use in chat and voice applications.
+// date selects the day and can only be today or tomorrow; the zero time
+// means today.
+func (c *Client) Overview(ctx context.Context, location *Coordinates, date time.Time) (*OverviewData, error) {
+	params := coordinateParams(location)
+	if !date.IsZero() {
+		params.Set("date", date.Format("2006-01-02"))
+	}
+
+	o := &OverviewData{
+		Unit: c.unit,
+		Lang: c.lang,
+	}
+	if err := c.get(ctx, c.dataURL("onecall/overview")+"?"+c.query(params).Encode(), o); err != nil {
+		return nil, err
+	}
+	return o, nil
+}
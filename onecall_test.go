@@ -0,0 +1,303 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"testing"
+	"time"
+)
+
+const oneCallResponse = `{
+  "lat": 33.44, "lon": -94.04, "timezone": "America/Chicago", "timezone_offset": -18000,
+  "current": {
+    "dt": 1684929490, "sunrise": 1684926645, "sunset": 1684977332, "temp": 292.55,
+    "feels_like": 292.87, "pressure": 1014, "humidity": 89, "dew_point": 290.69, "uvi": 0.16,
+    "clouds": 53, "visibility": 10000, "wind_speed": 3.13, "wind_deg": 93, "wind_gust": 6.71,
+    "weather": [{"id": 803, "main": "Clouds", "description": "broken clouds", "icon": "04d"}]
+  },
+  "minutely": [{"dt": 1684929540, "precipitation": 0}, {"dt": 1684929600, "precipitation": 0.2}],
+  "hourly": [{
+    "dt": 1684926000, "temp": 292.01, "feels_like": 292.33, "pressure": 1014, "humidity": 91,
+    "dew_point": 290.51, "uvi": 0, "clouds": 54, "visibility": 10000, "wind_speed": 2.58,
+    "wind_deg": 86, "wind_gust": 5.88, "pop": 0.15, "rain": {"1h": 0.25},
+    "weather": [{"id": 500, "main": "Rain", "description": "light rain", "icon": "10n"}]
+  }],
+  "daily": [{
+    "dt": 1684951200, "sunrise": 1684926645, "sunset": 1684977332, "moonrise": 1684941060,
+    "moonset": 1684905480, "moon_phase": 0.16, "summary": "Expect a day of partly cloudy with rain",
+    "temp": {"day": 299.03, "min": 290.69, "max": 300.35, "night": 291.45, "eve": 297.51, "morn": 292.55},
+    "feels_like": {"day": 299.21, "night": 291.37, "eve": 297.86, "morn": 292.87},
+    "pressure": 1016, "humidity": 59, "dew_point": 290.48, "wind_speed": 3.98, "wind_deg": 76,
+    "wind_gust": 8.92, "clouds": 92, "pop": 0.47, "rain": 0.15, "uvi": 9.23,
+    "weather": [{"id": 500, "main": "Rain", "description": "light rain", "icon": "10d"}]
+  }],
+  "alerts": [{
+    "sender_name": "NWS Philadelphia - Mount Holly", "event": "Small Craft Advisory",
+    "start": 1684952747, "end": 1684988747, "description": "...SMALL CRAFT ADVISORY...",
+    "tags": ["Wind"]
+  }]
+}`
+
+func TestClientOneCall(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/3.0/onecall" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("lat") != "33.44" || q.Get("lon") != "-94.04" || q.Get("units") != "metric" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, oneCallResponse)
+	}, WithStrictDecoding())
+
+	o, err := c.OneCall(context.Background(), &Coordinates{Latitude: 33.44, Longitude: -94.04})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if o.Timezone != "America/Chicago" || o.TimezoneOffset != -18000 {
+		t.Errorf("unexpected timezone %s %d", o.Timezone, o.TimezoneOffset)
+	}
+	if o.Current.WindGust != 6.71 || o.Current.Weather[0].ID != 803 {
+		t.Errorf("unexpected current block %+v", o.Current)
+	}
+	if len(o.Minutely) != 2 || o.Minutely[1].Precipitation != 0.2 || o.Minutely[1].Dt.Unix() != 1684929600 {
+		t.Errorf("unexpected minutely block %+v", o.Minutely)
+	}
+	if len(o.Hourly) != 1 || o.Hourly[0].Rain.OneH != 0.25 || o.Hourly[0].Pop != 0.15 {
+		t.Errorf("unexpected hourly block %+v", o.Hourly)
+	}
+	if len(o.Daily) != 1 || o.Daily[0].Temp.Max != 300.35 || o.Daily[0].FeelsLike.Morn != 292.87 {
+		t.Errorf("unexpected daily block %+v", o.Daily)
+	}
+	if len(o.Alerts) != 1 || o.Alerts[0].Event != "Small Craft Advisory" || o.Alerts[0].Tags[0] != "Wind" ||
+		o.Alerts[0].Sender != "NWS Philadelphia - Mount Holly" || o.Alerts[0].Start.Unix() != 1684952747 || o.Alerts[0].End.Unix() != 1684988747 {
+		t.Errorf("unexpected alerts block %+v", o.Alerts)
+	}
+	if o.Unit != "metric" {
+		t.Errorf("Expected unit metric, got %s", o.Unit)
+	}
+}
+
+func TestClientOneCallExclude(t *testing.T) {
+	t.Parallel()
+
+	var exclude []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		exclude = r.URL.Query()["exclude"]
+		fmt.Fprint(w, `{"lat":33.44,"lon":-94.04,"daily":[{"dt":1684951200}]}`)
+	})
+
+	location := &Coordinates{Latitude: 33.44, Longitude: -94.04}
+	o, err := c.OneCall(context.Background(), location, OneCallBlockMinutely, OneCallBlockHourly, OneCallBlockAlerts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(exclude) != 1 || exclude[0] != "minutely,hourly,alerts" {
+		t.Errorf("Expected exclude=minutely,hourly,alerts, got %v", exclude)
+	}
+	if len(o.Daily) != 1 || o.Hourly != nil {
+		t.Errorf("unexpected result %+v", o)
+	}
+
+	if _, err := c.OneCall(context.Background(), location); err != nil {
+		t.Fatal(err)
+	}
+	if exclude != nil {
+		t.Errorf("Expected no exclude parameter, got %v", exclude)
+	}
+}
+
+func TestClientOneCallTimemachine(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/3.0/onecall/timemachine" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("dt") != "1586468027" || q.Get("lat") != "39.099724" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{
+  "lat": 39.099724, "lon": -94.578331, "timezone": "America/Chicago", "timezone_offset": -18000,
+  "data": [{
+    "dt": 1586468027, "sunrise": 1586433008, "sunset": 1586479870, "temp": 16.2, "feels_like": 13.58,
+    "pressure": 1021, "humidity": 25, "dew_point": -3.99, "uvi": 6.41, "clouds": 0, "visibility": 10000,
+    "wind_speed": 2.1, "wind_deg": 300,
+    "weather": [{"id": 800, "main": "Clear", "description": "clear sky", "icon": "01d"}]
+  }]
+}`)
+	}, WithStrictDecoding())
+
+	dt := time.Date(2020, time.April, 9, 21, 33, 47, 0, time.UTC)
+	o, err := c.OneCallTimemachine(context.Background(), &Coordinates{Latitude: 39.099724, Longitude: -94.578331}, dt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(o.Data) != 1 || o.Data[0].Dt.Unix() != 1586468027 || o.Data[0].Temp != 16.2 || o.Data[0].Weather[0].ID != 800 {
+		t.Errorf("unexpected result %+v", o)
+	}
+	if o.Timezone != "America/Chicago" || o.Unit != "metric" {
+		t.Errorf("unexpected result %+v", o)
+	}
+}
+
+func TestClientDaySummary(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/3.0/onecall/day_summary" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if q := r.URL.Query(); q.Get("date") != "2020-03-04" || q.Get("tz") != "+02:00" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{
+  "lat": 33, "lon": 35, "tz": "+02:00", "date": "2020-03-04", "units": "metric",
+  "cloud_cover": {"afternoon": 0}, "humidity": {"afternoon": 33}, "precipitation": {"total": 1.2},
+  "temperature": {"min": 13.33, "max": 26.09, "afternoon": 23, "night": 16.41, "evening": 22.78, "morning": 14.44},
+  "pressure": {"afternoon": 1015}, "wind": {"max": {"speed": 8.7, "direction": 120}}
+}`)
+	}, WithStrictDecoding())
+
+	date := time.Date(2020, time.March, 4, 23, 30, 0, 0, time.FixedZone("EET", 2*60*60))
+	d, err := c.DaySummary(context.Background(), &Coordinates{Latitude: 33, Longitude: 35}, date)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Date != "2020-03-04" || d.Temperature.Max != 26.09 || d.Precipitation.Total != 1.2 {
+		t.Errorf("unexpected result %+v", d)
+	}
+	if d.Humidity.Afternoon != 33 || d.Wind.Max.Speed != 8.7 || d.Wind.Max.Direction != 120 {
+		t.Errorf("unexpected result %+v", d)
+	}
+}
+
+func TestClientOverview(t *testing.T) {
+	t.Parallel()
+
+	var dates []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/data/3.0/onecall/overview" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		dates = append(dates, r.URL.Query().Get("date"))
+		fmt.Fprint(w, `{
+  "lat": 51.509865, "lon": -0.118092, "tz": "+01:00", "date": "2024-05-13", "units": "metric",
+  "weather_overview": "The current weather is overcast with a temperature of 16°C."
+}`)
+	}, WithStrictDecoding())
+
+	location := &Coordinates{Latitude: 51.509865, Longitude: -0.118092}
+	o, err := c.Overview(context.Background(), location, time.Time{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if o.Date != "2024-05-13" || o.WeatherOverview != "The current weather is overcast with a temperature of 16°C." {
+		t.Errorf("unexpected result %+v", o)
+	}
+
+	if _, err := c.Overview(context.Background(), location, time.Date(2024, time.May, 14, 8, 0, 0, 0, time.UTC)); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"", "2024-05-14"}; !reflect.DeepEqual(dates, want) {
+		t.Errorf("Expected dates %q, got %q", want, dates)
+	}
+}
+
+func TestActiveAlerts(t *testing.T) {
+	t.Parallel()
+
+	var o OneCallData
+	if err := json.Unmarshal([]byte(`{"alerts":[
+		{"sender_name":"NWS","event":"Heat Advisory","start":1000,"end":2000,"tags":["Extreme temperature value"]},
+		{"sender_name":"NWS","event":"Flood Watch","start":1500,"end":3000,"tags":["Flood"]}
+	]}`), &o); err != nil {
+		t.Fatal(err)
+	}
+
+	tests := []struct {
+		at   int64
+		want []string
+	}{
+		{999, nil},
+		{1000, []string{"Heat Advisory"}},
+		{1500, []string{"Heat Advisory", "Flood Watch"}},
+		{2000, []string{"Flood Watch"}},
+		{3000, nil},
+	}
+	for _, tt := range tests {
+		var got []string
+		for _, a := range o.ActiveAlerts(time.Unix(tt.at, 0)) {
+			got = append(got, a.Event)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("%d: expected %v, got %v", tt.at, tt.want, got)
+		}
+	}
+
+	b, err := json.Marshal(o.Alerts[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := `{"sender_name":"NWS","event":"Heat Advisory","start":1000,"end":2000,"description":"","tags":["Extreme temperature value"]}`; string(b) != want {
+		t.Errorf("Expected %s, got %s", want, b)
+	}
+}
+
+func TestMinutelyPrecipitation(t *testing.T) {
+	t.Parallel()
+
+	start := time.Date(2023, 5, 24, 12, 0, 0, 0, time.UTC)
+	o := &OneCallData{}
+	for i := 0; i < 60; i++ {
+		var p float64
+		if i >= 10 && i < 40 {
+			p = 6 // 0.1 mm a minute
+		}
+		o.Minutely = append(o.Minutely, OneCallMinutely{Dt: start.Add(time.Duration(i) * time.Minute), Precipitation: p})
+	}
+
+	if next, ok := o.NextPrecipitation(); !ok || !next.Equal(start.Add(10*time.Minute)) {
+		t.Errorf("Expected precipitation from 12:10, got %v %v", next, ok)
+	}
+	for _, tt := range []struct {
+		d    time.Duration
+		want float64
+	}{
+		{10 * time.Minute, 0},
+		{15 * time.Minute, 0.5},
+		{time.Hour, 3},
+		{2 * time.Hour, 3},
+	} {
+		if got := o.PrecipitationInNext(tt.d); !almostEqual(got, tt.want) {
+			t.Errorf("%v: expected %v mm, got %v", tt.d, tt.want, got)
+		}
+	}
+
+	dry := &OneCallData{Minutely: o.Minutely[:10]}
+	if _, ok := dry.NextPrecipitation(); ok {
+		t.Error("Expected no precipitation")
+	}
+	if got := (&OneCallData{}).PrecipitationInNext(time.Hour); got != 0 {
+		t.Errorf("Expected no precipitation without a minutely block, got %v", got)
+	}
+}
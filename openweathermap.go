@@ -15,8 +15,12 @@
 package openweathermap
 
 import (
+	"crypto/tls"
 	"errors"
 	"net/http"
+	"net/url"
+	"strings"
+	"time"
 )
 
 var errUnitUnavailable = errors.New("unit unavailable")
@@ -25,19 +29,20 @@ var errInvalidKey = errors.New("invalid api key")
 var errInvalidOption = errors.New("invalid option")
 var errInvalidHttpClient = errors.New("invalid http client")
 var errForecastUnavailable = errors.New("forecast unavailable")
+var errForecastCount = errors.New("forecast count out of range")
+var errTransportUnavailable = errors.New("http client transport is not an *http.Transport")
 
 // DataUnits represents the character chosen to represent the temperature notation
 var DataUnits = map[string]string{"C": "metric", "F": "imperial", "K": "internal"}
 var (
-	baseURL        = "http://api.openweathermap.org/data/2.5/weather?%s"
-	iconURL        = "http://openweathermap.org/img/w/%s"
-	stationURL     = "http://api.openweathermap.org/data/2.5/station?id=%d"
-	forecast5Base  = "http://api.openweathermap.org/data/2.5/forecast?appid=%s&%s&mode=json&units=%s&lang=%s&cnt=%d"
-	forecast16Base = "http://api.openweathermap.org/data/2.5/forecast/daily?appid=%s&%s&mode=json&units=%s&lang=%s&cnt=%d"
-	historyURL     = "http://api.openweathermap.org/data/2.5/history/%s"
-	pollutionURL   = "http://api.openweathermap.org/pollution/v1/co/"
-	uvURL          = "http://api.openweathermap.org/data/2.5/"
-	dataPostURL    = "http://openweathermap.org/data/post"
+	iconURL       = "https://openweathermap.org/img/wn/%s"
+	pollutionURL  = "http://api.openweathermap.org/pollution/v1/co/"
+	dataPostURL   = "http://openweathermap.org/data/post"
+	geocodeURL    = "http://api.openweathermap.org/geo/1.0/direct?%s"
+	geocodeZipURL = "http://api.openweathermap.org/geo/1.0/zip?%s"
+	weatherMapURL = "https://maps.openweathermap.org/maps/2.0/weather/%s/%d/%d/%d?%s"
+	tileURL       = "https://tile.openweathermap.org/map/%s/%d/%d/%d.png?%s"
+	agroURL       = "http://api.agromonitoring.com/agro/1.0/%s?%s"
 )
 
 // LangCodes holds all supported languages to be used
@@ -95,18 +100,19 @@ type Coordinates struct {
 // Sys struct contains general information about the request
 // and the surrounding area for where the request was made.
 type Sys struct {
-	Type    int     `json:"type"`
-	ID      int     `json:"id"`
-	Message float64 `json:"message"`
-	Country string  `json:"country"`
-	Sunrise int     `json:"sunrise"`
-	Sunset  int     `json:"sunset"`
+	Type    int       `json:"type"`
+	ID      int       `json:"id"`
+	Message float64   `json:"message"`
+	Country string    `json:"country"`
+	Sunrise Timestamp `json:"sunrise"`
+	Sunset  Timestamp `json:"sunset"`
 }
 
 // Wind struct contains the speed and degree of the wind.
 type Wind struct {
 	Speed float64 `json:"speed"`
 	Deg   float64 `json:"deg"`
+	Gust  float64 `json:"gust"`
 }
 
 // Weather struct holds high-level, basic info on the returned
@@ -190,13 +196,58 @@ func (c *Config) CheckAPIKeyExists() bool { return len(c.APIKey) > 1 }
 
 // Settings holds the client settings
 type Settings struct {
-	client *http.Client
+	client          *http.Client
+	strict          bool
+	raw             func(u *url.URL, body []byte)
+	timeout         time.Duration
+	maxResponseSize int64
+	proxy           *url.URL
+	tlsConfig       *tls.Config
+	keys            *keyPool
+	batchInterval   time.Duration
+	maintenance     func(active bool)
+	plan            Plan
+	versions        map[string]string
+	xml             bool
+	iconDir         string
 }
 
-// NewSettings returns a new Setting pointer with default http client.
+// defaultTransport is shared by every client that isn't given its own
+// http client, so connections to the API are kept alive and reused
+// across all WeatherData values and Clients.  net/http only keeps 2 idle
+// connections per host by default, which forces new connections under
+// concurrent load.
+var defaultTransport = func() *http.Transport {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.MaxIdleConns = 100
+	t.MaxIdleConnsPerHost = 32
+	t.IdleConnTimeout = 90 * time.Second
+	return t
+}()
+
+// DefaultTransport returns the transport shared by every client that
+// isn't given its own http client.  Transports wrapping the one of the
+// package, such as those of the tracing and metrics packages, should
+// wrap it rather than http.DefaultTransport to keep its connection
+// reuse.
+func DefaultTransport() http.RoundTripper {
+	return defaultTransport
+}
+
+// defaultClient is the http client used unless WithHttpClient is given.
+var defaultClient = &http.Client{Transport: defaultTransport}
+
+// DefaultMaxResponseSize is the largest response body, in bytes, read by
+// default.  It can be changed with WithMaxResponseSize.
+const DefaultMaxResponseSize = 10 << 20
+
+// NewSettings returns a new Setting pointer with the shared default http
+// client.
 func NewSettings() *Settings {
 	return &Settings{
-		client: http.DefaultClient,
+		client:          defaultClient,
+		maxResponseSize: DefaultMaxResponseSize,
+		batchInterval:   DefaultBatchInterval,
 	}
 }
 
@@ -214,6 +265,107 @@ func WithHttpClient(c *http.Client) Option {
 	}
 }
 
+// WithStrictDecoding makes decoding fail when a response contains a
+// field the target struct doesn't define.  This helps to detect OWM
+// adding or renaming fields an application depends on.
+func WithStrictDecoding() Option {
+	return func(s *Settings) error {
+		s.strict = true
+		return nil
+	}
+}
+
+// WithXMLMode requests the current weather in XML instead of JSON, for
+// systems that need the XML responses passed to WithRawResponse.  The
+// XML is decoded into the same CurrentWeatherData; it doesn't carry the
+// Main group of the weather conditions, Sys.Type, Sys.ID, Base and Cod.
+// The forecasts, which have no XML decoding, return an error in XML
+// mode rather than silently falling back to JSON; other endpoints keep
+// using JSON.
+func WithXMLMode() Option {
+	return func(s *Settings) error {
+		s.xml = true
+		return nil
+	}
+}
+
+// WithIconCache makes Client.Icon and Client.IconBytes keep the icons
+// they download in the directory dir, which must exist, and serve them
+// from there afterwards.
+func WithIconCache(dir string) Option {
+	return func(s *Settings) error {
+		if dir == "" {
+			return errInvalidOption
+		}
+		s.iconDir = dir
+		return nil
+	}
+}
+
+// WithRawResponse registers fn to be called with the exact body of every
+// response, before it is decoded.  u is the request URL with the API key
+// removed.  fn must not modify body.
+func WithRawResponse(fn func(u *url.URL, body []byte)) Option {
+	return func(s *Settings) error {
+		if fn == nil {
+			return errInvalidOption
+		}
+		s.raw = fn
+		return nil
+	}
+}
+
+// WithTimeout sets the time limit for a whole request, including reading
+// the response body.  It doesn't modify the http client passed to
+// WithHttpClient but uses a copy of it.
+func WithTimeout(d time.Duration) Option {
+	return func(s *Settings) error {
+		if d <= 0 {
+			return errInvalidOption
+		}
+		s.timeout = d
+		return nil
+	}
+}
+
+// WithMaxResponseSize sets the largest response body, in bytes, that is
+// read before failing with ErrResponseTooLarge.
+func WithMaxResponseSize(n int64) Option {
+	return func(s *Settings) error {
+		if n <= 0 {
+			return errInvalidOption
+		}
+		s.maxResponseSize = n
+		return nil
+	}
+}
+
+// WithProxy sends all requests through the proxy at proxyURL, e.g.
+// "http://proxy.example.com:3128".  The http client's transport must be
+// an *http.Transport; it is cloned, not modified.
+func WithProxy(proxyURL *url.URL) Option {
+	return func(s *Settings) error {
+		if proxyURL == nil {
+			return errInvalidOption
+		}
+		s.proxy = proxyURL
+		return nil
+	}
+}
+
+// WithTLSConfig sets the TLS configuration used to connect to the API,
+// e.g. to trust a custom CA bundle.  The http client's transport must be
+// an *http.Transport; it is cloned, not modified.
+func WithTLSConfig(cfg *tls.Config) Option {
+	return func(s *Settings) error {
+		if cfg == nil {
+			return errInvalidOption
+		}
+		s.tlsConfig = cfg
+		return nil
+	}
+}
+
 // setOptions sets Optional client settings to the Settings pointer
 func setOptions(settings *Settings, options []Option) error {
 	for _, option := range options {
@@ -225,5 +377,94 @@ func setOptions(settings *Settings, options []Option) error {
 			return err
 		}
 	}
+
+	return settings.configureClient()
+}
+
+// configureClient applies the timeout, proxy, TLS, plan and API key
+// settings to a copy of the http client, leaving the original untouched.
+func (s *Settings) configureClient() error {
+	if s.timeout == 0 && s.proxy == nil && s.tlsConfig == nil && s.keys == nil && s.plan == PlanFree {
+		return nil
+	}
+
+	c := *s.client
+	if s.timeout > 0 {
+		c.Timeout = s.timeout
+	}
+
+	if s.proxy != nil || s.tlsConfig != nil {
+		rt := c.Transport
+		if rt == nil {
+			rt = http.DefaultTransport
+		}
+		base, ok := rt.(*http.Transport)
+		if !ok {
+			return errTransportUnavailable
+		}
+		t := base.Clone()
+		if s.proxy != nil {
+			t.Proxy = http.ProxyURL(s.proxy)
+		}
+		if s.tlsConfig != nil {
+			t.TLSClientConfig = s.tlsConfig
+		}
+		c.Transport = t
+	}
+
+	if s.plan == PlanPaid {
+		next := c.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		c.Transport = &proRouter{next: next}
+	}
+
+	if s.keys != nil {
+		next := c.Transport
+		if next == nil {
+			next = http.DefaultTransport
+		}
+		c.Transport = &keyRotator{pool: s.keys, next: next}
+	}
+
+	s.client = &c
 	return nil
 }
+
+// Endpoint reduces a request URL to a low cardinality endpoint label,
+// e.g. "weather", "forecast/daily" or "uvi/history".  The API version
+// prefix, query parameters and any location values in the path are
+// dropped.
+func Endpoint(u *url.URL) string {
+	path := strings.Trim(u.Path, "/")
+	if strings.HasPrefix(path, "data/") {
+		path = strings.TrimPrefix(path, "data/")
+		if i := strings.Index(path, "/"); i >= 0 {
+			path = path[i+1:]
+		}
+	}
+
+	segments := strings.Split(path, "/")
+	ep := segments[0]
+	if len(segments) > 1 && isWord(segments[1]) {
+		ep += "/" + segments[1]
+	}
+	if ep == "" {
+		return "unknown"
+	}
+	return ep
+}
+
+// isWord reports whether s only contains letters and underscores.
+func isWord(s string) bool {
+	if s == "" {
+		return false
+	}
+	for _, r := range s {
+		if (r < 'a' || r > 'z') && (r < 'A' || r > 'Z') && r != '_' {
+			return false
+		}
+	}
+	return true
+}
@@ -15,6 +15,9 @@
 package openweathermap
 
 import (
+	"crypto/tls"
+	"net/http"
+	"net/url"
 	"testing"
 )
 
@@ -63,3 +66,75 @@ func TestSetOptionsWithEmpty(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestEndpoint(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"http://api.openweathermap.org/data/2.5/weather?q=Philadelphia":        "weather",
+		"http://api.openweathermap.org/data/2.5/forecast/daily?id=1&cnt=3":     "forecast/daily",
+		"http://api.openweathermap.org/data/2.5/uvi/history?lat=1&lon=2":       "uvi/history",
+		"https://api.openweathermap.org/data/3.0/onecall/timemachine?dt=1":     "onecall/timemachine",
+		"http://api.openweathermap.org/pollution/v1/co/0,10/current.json":      "pollution",
+		"http://api.openweathermap.org/data/2.5/history/city?appid=x&q=Denver": "history/city",
+		"http://api.openweathermap.org/":                                       "unknown",
+	}
+
+	for raw, want := range tests {
+		u, err := url.Parse(raw)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Endpoint(u); got != want {
+			t.Errorf("Endpoint(%s) = %s, want %s", raw, got, want)
+		}
+	}
+}
+
+func TestProxyAndTLSOptions(t *testing.T) {
+	t.Parallel()
+
+	proxy, _ := url.Parse("http://proxy.example.com:3128")
+	cfg := &tls.Config{ServerName: "api.openweathermap.org"}
+
+	s := NewSettings()
+	if err := setOptions(s, []Option{WithProxy(proxy), WithTLSConfig(cfg)}); err != nil {
+		t.Fatal(err)
+	}
+
+	tr, ok := s.client.Transport.(*http.Transport)
+	if !ok {
+		t.Fatalf("Expected an *http.Transport, got %T", s.client.Transport)
+	}
+	if tr.TLSClientConfig != cfg {
+		t.Error("TLS config not applied")
+	}
+	req, _ := http.NewRequest("GET", "https://api.openweathermap.org/data/2.5/weather", nil)
+	if u, err := tr.Proxy(req); err != nil || u.String() != proxy.String() {
+		t.Errorf("Expected proxy %s, got %v (%v)", proxy, u, err)
+	}
+	if http.DefaultClient.Transport != nil {
+		t.Error("http.DefaultClient was modified")
+	}
+
+	custom := &http.Client{Transport: roundTripperFunc(nil)}
+	if err := setOptions(NewSettings(), []Option{WithHttpClient(custom), WithProxy(proxy)}); err != errTransportUnavailable {
+		t.Errorf("Expected %v, got %v", errTransportUnavailable, err)
+	}
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+func TestNewSettingsSharesTransport(t *testing.T) {
+	t.Parallel()
+
+	a, b := NewSettings(), NewSettings()
+	if a.client != b.client || a.client.Transport != defaultTransport {
+		t.Error("Expected settings to share the default client and transport")
+	}
+	if defaultTransport.MaxIdleConnsPerHost <= http.DefaultMaxIdleConnsPerHost {
+		t.Errorf("Expected more than %d idle connections per host, got %d", http.DefaultMaxIdleConnsPerHost, defaultTransport.MaxIdleConnsPerHost)
+	}
+}
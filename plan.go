@@ -0,0 +1,72 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"net/http"
+)
+
+// Hosts of the API.  Paid subscriptions are served by proHost, which
+// also serves the endpoints only they can use.
+const (
+	apiHost = "api.openweathermap.org"
+	proHost = "pro.openweathermap.org"
+)
+
+// Plan is the kind of subscription of the API keys.
+type Plan int
+
+const (
+	// PlanFree sends requests to api.openweathermap.org, except those
+	// of the endpoints only paid subscriptions can use, such as the
+	// hourly and climate forecasts, which always go to
+	// pro.openweathermap.org.
+	PlanFree Plan = iota
+	// PlanPaid sends every request for api.openweathermap.org to
+	// pro.openweathermap.org, as OWM asks paying subscribers to.
+	PlanPaid
+)
+
+// WithPlan sets the plan of the API keys, which selects the host the
+// requests are sent to.  The default is PlanFree.
+func WithPlan(p Plan) Option {
+	return func(s *Settings) error {
+		if p != PlanFree && p != PlanPaid {
+			return errInvalidOption
+		}
+		s.plan = p
+		return nil
+	}
+}
+
+// proRouter is an http.RoundTripper sending the requests for apiHost to
+// proHost.
+type proRouter struct {
+	next http.RoundTripper
+}
+
+// RoundTrip implements http.RoundTripper.
+func (r *proRouter) RoundTrip(req *http.Request) (*http.Response, error) {
+	if req.URL.Host != apiHost {
+		return r.next.RoundTrip(req)
+	}
+	u := *req.URL
+	u.Scheme = "https"
+	u.Host = proHost
+	routed := req.Clone(req.Context())
+	routed.URL = &u
+	routed.Host = proHost
+	return r.next.RoundTrip(routed)
+}
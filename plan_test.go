@@ -0,0 +1,59 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"testing"
+)
+
+func TestWithPlan(t *testing.T) {
+	t.Parallel()
+
+	for _, tt := range []struct {
+		plan Plan
+		want []string
+	}{
+		{PlanFree, []string{"http://api.openweathermap.org/data/2.5/weather", "https://pro.openweathermap.org/data/2.5/forecast/hourly"}},
+		{PlanPaid, []string{"https://pro.openweathermap.org/data/2.5/weather", "https://pro.openweathermap.org/data/2.5/forecast/hourly"}},
+	} {
+		var mu sync.Mutex
+		var got []string
+		c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			got = append(got, r.URL.Scheme+"://"+r.Host+r.URL.Path)
+			mu.Unlock()
+			fmt.Fprint(w, `{}`)
+		}, WithPlan(tt.plan))
+
+		ctx := context.Background()
+		if _, err := c.CurrentByName(ctx, "Berlin"); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.ForecastHourlyByID(ctx, 2950159, 1); err != nil {
+			t.Fatal(err)
+		}
+		if fmt.Sprint(got) != fmt.Sprint(tt.want) {
+			t.Errorf("plan %d: expected %v, got %v", tt.plan, tt.want, got)
+		}
+	}
+
+	if _, err := NewClient("c", "en", testKey, WithPlan(Plan(5))); err != errInvalidOption {
+		t.Errorf("Expected %v, got %v", errInvalidOption, err)
+	}
+}
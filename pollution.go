@@ -1,7 +1,6 @@
 package openweathermap
 
 import (
-	"encoding/json"
 	"fmt"
 	"strconv"
 )
@@ -74,7 +73,7 @@ func (p *Pollution) PollutionByParams(params *PollutionParameters) error {
 	}
 	defer response.Body.Close()
 
-	if err = json.NewDecoder(response.Body).Decode(&p); err != nil {
+	if err = p.decode(response, &p); err != nil {
 		return err
 	}
 
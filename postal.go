@@ -0,0 +1,89 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"errors"
+	"net/url"
+	"strings"
+)
+
+var errInvalidPostalCode = errors.New("postal code must be 1 to 10 letters, digits, spaces or hyphens")
+var errInvalidCountryCode = errors.New("country code must be an ISO 3166-1 alpha-2 code such as US or GB")
+
+// maxPostalCodeLength is the length of the longest postal codes in use.
+const maxPostalCodeLength = 10
+
+// countryCodes holds the ISO 3166-1 alpha-2 country codes.
+var countryCodes = func() map[string]bool {
+	m := map[string]bool{}
+	for _, cc := range strings.Fields(`
+		AD AE AF AG AI AL AM AO AQ AR AS AT AU AW AX AZ BA BB BD BE BF BG BH BI BJ BL BM BN BO BQ BR BS BT BV BW BY BZ
+		CA CC CD CF CG CH CI CK CL CM CN CO CR CU CV CW CX CY CZ DE DJ DK DM DO DZ EC EE EG EH ER ES ET FI FJ FK FM FO
+		FR GA GB GD GE GF GG GH GI GL GM GN GP GQ GR GS GT GU GW GY HK HM HN HR HT HU ID IE IL IM IN IO IQ IR IS IT JE
+		JM JO JP KE KG KH KI KM KN KP KR KW KY KZ LA LB LC LI LK LR LS LT LU LV LY MA MC MD ME MF MG MH MK ML MM MN MO
+		MP MQ MR MS MT MU MV MW MX MY MZ NA NC NE NF NG NI NL NO NP NR NU NZ OM PA PE PF PG PH PK PL PM PN PR PS PT PW
+		PY QA RE RO RS RU RW SA SB SC SD SE SG SH SI SJ SK SL SM SN SO SR SS ST SV SX SY SZ TC TD TF TG TH TJ TK TL TM
+		TN TO TR TT TV TW TZ UA UG UM US UY UZ VA VC VE VG VI VN VU WF WS YE YT ZA ZM ZW`) {
+		m[cc] = true
+	}
+	return m
+}()
+
+// PostalCode is a zip or postal code in a country.
+type PostalCode struct {
+	Code    string
+	Country string
+}
+
+// NewPostalCode returns the PostalCode of code in country, trimmed and
+// uppercased.  country is an ISO 3166-1 alpha-2 code; UK is accepted for
+// GB.
+func NewPostalCode(code, country string) (PostalCode, error) {
+	p := PostalCode{
+		Code:    strings.ToUpper(strings.TrimSpace(code)),
+		Country: strings.ToUpper(strings.TrimSpace(country)),
+	}
+	if p.Country == "UK" {
+		p.Country = "GB"
+	}
+
+	if p.Code == "" || len(p.Code) > maxPostalCodeLength {
+		return PostalCode{}, errInvalidPostalCode
+	}
+	for _, r := range p.Code {
+		if (r < 'A' || r > 'Z') && (r < '0' || r > '9') && r != ' ' && r != '-' {
+			return PostalCode{}, errInvalidPostalCode
+		}
+	}
+	if !countryCodes[p.Country] {
+		return PostalCode{}, errInvalidCountryCode
+	}
+	return p, nil
+}
+
+// String returns the postal code in the form OWM expects, e.g. "19125,US".
+func (p PostalCode) String() string {
+	return p.Code + "," + p.Country
+}
+
+// zipParams returns the zip query parameter for zip in the country
+// countryCode.
+func zipParams(zip, countryCode string) (url.Values, error) {
+	p, err := NewPostalCode(zip, countryCode)
+	if err != nil {
+		return nil, err
+	}
+	return url.Values{"zip": {p.String()}}, nil
+}
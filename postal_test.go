@@ -0,0 +1,74 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestNewPostalCode(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		code, country string
+		want          string
+		err           error
+	}{
+		{"19125", "US", "19125,US", nil},
+		{" e14 9gf ", "gb", "E14 9GF,GB", nil},
+		{"SW1A 1AA", "UK", "SW1A 1AA,GB", nil},
+		{"1010-001", "pt", "1010-001,PT", nil},
+		{"", "US", "", errInvalidPostalCode},
+		{"12345678901", "US", "", errInvalidPostalCode},
+		{"19125;", "US", "", errInvalidPostalCode},
+		{"19125", "USA", "", errInvalidCountryCode},
+		{"19125", "XX", "", errInvalidCountryCode},
+		{"19125", "", "", errInvalidCountryCode},
+	}
+
+	for _, tt := range tests {
+		p, err := NewPostalCode(tt.code, tt.country)
+		if err != tt.err {
+			t.Errorf("NewPostalCode(%q, %q): expected error %v, got %v", tt.code, tt.country, tt.err, err)
+			continue
+		}
+		if err == nil && p.String() != tt.want {
+			t.Errorf("NewPostalCode(%q, %q): expected %s, got %s", tt.code, tt.country, tt.want, p)
+		}
+	}
+}
+
+func TestClientZipValidation(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	})
+
+	ctx := context.Background()
+	if _, err := c.CurrentByZip(ctx, "19125", "USA"); err != errInvalidCountryCode {
+		t.Errorf("Expected %v, got %v", errInvalidCountryCode, err)
+	}
+	if _, err := c.Forecast5ByZip(ctx, "", "US", 1); err != errInvalidPostalCode {
+		t.Errorf("Expected %v, got %v", errInvalidPostalCode, err)
+	}
+	if _, err := c.Forecast16ByZip(ctx, "19125", "ZZ", 1); err != errInvalidCountryCode {
+		t.Errorf("Expected %v, got %v", errInvalidCountryCode, err)
+	}
+	if _, err := c.GeocodeZip(ctx, "19125/", "US"); err != errInvalidPostalCode {
+		t.Errorf("Expected %v, got %v", errInvalidPostalCode, err)
+	}
+}
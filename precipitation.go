@@ -0,0 +1,106 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+// PrecipitationType is the kind of precipitation reaching the ground.
+type PrecipitationType string
+
+// Precipitation types returned by InferPrecipitation.
+const (
+	PrecipitationNone         PrecipitationType = "none"
+	PrecipitationRain         PrecipitationType = "rain"
+	PrecipitationSleet        PrecipitationType = "sleet"
+	PrecipitationFreezingRain PrecipitationType = "freezing rain"
+	PrecipitationSnow         PrecipitationType = "snow"
+)
+
+// sleetMaxSurfaceTemp is the surface temperature, in celsius, up to which
+// snow falling through cold air is assumed to reach the ground partly
+// melted.
+const sleetMaxSurfaceTemp = 2.0
+
+// InferPrecipitation returns the likely precipitation type for the
+// weather condition ID conditionID.  Conditions without precipitation,
+// including the thunderstorms without rain or drizzle, are
+// PrecipitationNone.  Codes that name the type (snow, sleet, freezing
+// rain) are taken as is.  Reported rain, drizzle and thunderstorms with
+// rain stay rain, freezing rain at sub-zero surface temperatures, unless
+// the upper air data shows no warm layer above the ground.  Then, as for
+// "rain and snow", the type is inferred from the temperature profile:
+// surface is the temperature at the ground and aloft the warmest
+// temperature above it, both in celsius.  Callers without upper air data
+// can pass surface for both.
+func InferPrecipitation(conditionID int, surface, aloft float64) PrecipitationType {
+	switch {
+	case conditionID == 511:
+		return PrecipitationFreezingRain
+	case conditionID >= 611 && conditionID <= 613:
+		return PrecipitationSleet
+	case conditionID >= 600 && conditionID <= 602, conditionID >= 620 && conditionID <= 622:
+		return PrecipitationSnow
+	case ConditionID(conditionID).IsPrecipitation():
+	default:
+		return PrecipitationNone
+	}
+
+	if conditionID < 600 && (aloft == surface || aloft > 0) {
+		if surface <= 0 {
+			return PrecipitationFreezingRain
+		}
+		return PrecipitationRain
+	}
+
+	switch {
+	case aloft > 0 && surface <= 0:
+		// Rain from a warm layer freezing on contact with the ground.
+		return PrecipitationFreezingRain
+	case surface <= 0:
+		return PrecipitationSnow
+	case surface <= sleetMaxSurfaceTemp:
+		return PrecipitationSleet
+	default:
+		return PrecipitationRain
+	}
+}
+
+// Precipitation returns the likely type of the precipitation reported in
+// w, inferred from its conditions and the temperature at the ground, see
+// InferPrecipitation.  It is PrecipitationNone if no precipitation is
+// reported.
+func (w *CurrentWeatherData) Precipitation() PrecipitationType {
+	temp, _ := FromAPI(QuantityTemperature, w.Main.Temp, w.Unit, "°C")
+	for _, c := range w.Weather {
+		if p := InferPrecipitation(c.ID, temp, temp); p != PrecipitationNone {
+			return p
+		}
+	}
+	return PrecipitationNone
+}
+
+// Severity returns the highest severity of the conditions in w, see
+// ConditionID.Severity.  Rain inferred to freeze on the ground counts as
+// heavy, like the freezing rain condition.
+func (w *CurrentWeatherData) Severity() Severity {
+	s := SeverityNone
+	for _, c := range w.Weather {
+		if cs := c.Severity(); cs > s {
+			s = cs
+		}
+	}
+	if s < SeverityHeavy && w.Precipitation() == PrecipitationFreezingRain {
+		s = SeverityHeavy
+	}
+	return s
+}
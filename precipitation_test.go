@@ -0,0 +1,78 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import "testing"
+
+func TestInferPrecipitation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id             int
+		surface, aloft float64
+		want           PrecipitationType
+	}{
+		{800, -5, -5, PrecipitationNone},
+		{741, 0, 0, PrecipitationNone},
+		{601, 10, 10, PrecipitationSnow},
+		{611, 10, 10, PrecipitationSleet},
+		{511, 10, 10, PrecipitationFreezingRain},
+		{500, 12, 12, PrecipitationRain},
+		{500, -2, 3, PrecipitationFreezingRain},
+		{500, -2, -2, PrecipitationFreezingRain},
+		{500, 1, 1, PrecipitationRain},
+		{500, -2, -4, PrecipitationSnow},
+		{500, 4, -1, PrecipitationRain},
+		{616, 1, 1, PrecipitationSleet},
+		{616, 5, 5, PrecipitationRain},
+		{301, 0.5, -1, PrecipitationSleet},
+		{201, 25, 25, PrecipitationRain},
+		{211, 25, 25, PrecipitationNone},
+		{221, -5, -5, PrecipitationNone},
+	}
+
+	for _, tt := range tests {
+		if got := InferPrecipitation(tt.id, tt.surface, tt.aloft); got != tt.want {
+			t.Errorf("InferPrecipitation(%d, %v, %v) = %s, want %s", tt.id, tt.surface, tt.aloft, got, tt.want)
+		}
+	}
+}
+
+func TestCurrentWeatherPrecipitation(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		weather  []Weather
+		temp     float64
+		unit     string
+		want     PrecipitationType
+		severity Severity
+	}{
+		{[]Weather{{ID: 800}}, 20, "metric", PrecipitationNone, SeverityNone},
+		{[]Weather{{ID: 211}}, 20, "metric", PrecipitationNone, SeverityModerate},
+		{[]Weather{{ID: 701}, {ID: 500}}, 30.2, "imperial", PrecipitationFreezingRain, SeverityHeavy},
+		{[]Weather{{ID: 500}}, 274.15, "internal", PrecipitationRain, SeverityLight},
+		{[]Weather{{ID: 511}}, 5, "metric", PrecipitationFreezingRain, SeverityHeavy},
+	}
+	for _, tt := range tests {
+		w := &CurrentWeatherData{Weather: tt.weather, Main: Main{Temp: tt.temp}, Unit: tt.unit}
+		if got := w.Precipitation(); got != tt.want {
+			t.Errorf("%v: expected %s, got %s", tt.weather, tt.want, got)
+		}
+		if got := w.Severity(); got != tt.severity {
+			t.Errorf("%v: expected severity %s, got %s", tt.weather, tt.severity, got)
+		}
+	}
+}
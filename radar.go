@@ -0,0 +1,112 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"image"
+	"image/color"
+	"image/color/palette"
+	"image/draw"
+	"image/gif"
+	"image/png"
+	"io"
+	"time"
+)
+
+var errInvalidRadar = errors.New("invalid radar bounding box, zoom or hours")
+var errRadarTooLarge = errors.New("radar needs too many map tiles")
+
+// MaxRadarTiles is the largest number of map tiles a radar frame is made
+// of; every tile of every frame is a request.
+const MaxRadarTiles = 16
+
+// MaxRadarHours is the longest period RadarGIF animates.
+const MaxRadarHours = 24
+
+const (
+	radarDelay     = 50  // 1/100s of a second per frame
+	radarLastDelay = 200 // pause on the latest frame before looping
+)
+
+// radarBackground is drawn below the transparent precipitation tiles.
+var radarBackground = color.RGBA{R: 0xf4, G: 0xf4, B: 0xf4, A: 0xff}
+
+// RadarGIF writes to w an animated GIF of the precipitation over box,
+// with one frame per hour from hours ago until now.  zoom is the map zoom
+// level, from 0 for the whole world to 18.  The frames only show
+// precipitation, so they're meant to be laid over a base map of the same
+// area.  Historical map layers require a Weather Maps 2.0 subscription.
+func (c *Client) RadarGIF(ctx context.Context, w io.Writer, box BoundingBox, zoom, hours int) error {
+	px, ok := boxPixels(box, zoom)
+	if !ok || hours < 1 || hours > MaxRadarHours {
+		return errInvalidRadar
+	}
+	if tileCount(px) > MaxRadarTiles {
+		return errRadarTooLarge
+	}
+
+	now := time.Now().Truncate(time.Hour)
+	anim := &gif.GIF{}
+	for i := hours; i >= 0; i-- {
+		frame, err := c.radarFrame(ctx, px, zoom, now.Add(-time.Duration(i)*time.Hour))
+		if err != nil {
+			return err
+		}
+		p := image.NewPaletted(frame.Bounds(), palette.WebSafe)
+		draw.FloydSteinberg.Draw(p, p.Bounds(), frame, image.Point{})
+		anim.Image = append(anim.Image, p)
+		anim.Delay = append(anim.Delay, radarDelay)
+	}
+	anim.Delay[len(anim.Delay)-1] = radarLastDelay
+	return gif.EncodeAll(w, anim)
+}
+
+// radarFrame returns the precipitation at date over px, the pixels of the
+// map of the world at zoom.
+func (c *Client) radarFrame(ctx context.Context, px image.Rectangle, zoom int, date time.Time) (*image.RGBA, error) {
+	tiles, err := stitchTiles(ctx, px, func(ctx context.Context, x, y int) (image.Image, error) {
+		return c.radarTile(ctx, zoom, x, y, date)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	img := image.NewRGBA(tiles.Bounds())
+	draw.Draw(img, img.Bounds(), image.NewUniform(radarBackground), image.Point{}, draw.Src)
+	draw.Draw(img, img.Bounds(), tiles, image.Point{}, draw.Over)
+	return img, nil
+}
+
+// radarTile returns the precipitation map tile x, y at zoom for date.
+func (c *Client) radarTile(ctx context.Context, zoom, x, y int, date time.Time) (image.Image, error) {
+	return c.Maps().GetWeatherTile(ctx, WeatherPrecipitation, zoom, x, y, &TileOptions{Date: date})
+}
+
+// getPNG sends a GET request for u and decodes the PNG image it returns.
+func (c *Client) getPNG(ctx context.Context, u string) (image.Image, error) {
+	response, err := c.send(ctx, u)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+
+	body, err := c.readBody(response)
+	if err != nil {
+		return nil, err
+	}
+	return png.Decode(bytes.NewReader(body))
+}
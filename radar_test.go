@@ -0,0 +1,98 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/gif"
+	"image/png"
+	"net/http"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+)
+
+func TestRadarGIF(t *testing.T) {
+	t.Parallel()
+
+	tile := image.NewNRGBA(image.Rect(0, 0, tileSize, tileSize))
+	tile.Set(0, 0, color.NRGBA{B: 0xff, A: 0xff})
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, tile); err != nil {
+		t.Fatal(err)
+	}
+
+	path := regexp.MustCompile(`^/maps/2.0/weather/PR0/5/\d+/\d+$`)
+	var mu sync.Mutex
+	dates := map[int64]bool{}
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if !path.MatchString(r.URL.Path) {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		d, err := strconv.ParseInt(r.URL.Query().Get("date"), 10, 64)
+		if err != nil {
+			t.Error(err)
+		}
+		mu.Lock()
+		dates[d] = true
+		mu.Unlock()
+		w.Write(buf.Bytes())
+	})
+
+	box := BoundingBox{South: 47, West: 5, North: 55, East: 15}
+	var out bytes.Buffer
+	if err := c.RadarGIF(context.Background(), &out, box, 5, 3); err != nil {
+		t.Fatal(err)
+	}
+
+	anim, err := gif.DecodeAll(&out)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(anim.Image) != 4 || len(dates) != 4 {
+		t.Errorf("Expected 4 frames from 4 dates, got %d frames and %d dates", len(anim.Image), len(dates))
+	}
+	if anim.Delay[3] != radarLastDelay {
+		t.Errorf("Expected a pause on the last frame, got %v", anim.Delay)
+	}
+	x0, y0 := tilePixel(box.North, box.West, 5)
+	x1, y1 := tilePixel(box.South, box.East, 5)
+	if b := anim.Image[0].Bounds(); b.Dx() < int(x1-x0) || b.Dy() < int(y1-y0) {
+		t.Errorf("Expected the frame to cover the box, got %v", b)
+	}
+}
+
+func TestRadarGIFInvalid(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected request")
+	})
+
+	ctx := context.Background()
+	var out bytes.Buffer
+	if err := c.RadarGIF(ctx, &out, BoundingBox{South: 55, West: 5, North: 47, East: 15}, 5, 3); err != errInvalidRadar {
+		t.Errorf("Expected %v, got %v", errInvalidRadar, err)
+	}
+	if err := c.RadarGIF(ctx, &out, BoundingBox{South: 47, West: 5, North: 55, East: 15}, 5, 0); err != errInvalidRadar {
+		t.Errorf("Expected %v, got %v", errInvalidRadar, err)
+	}
+	if err := c.RadarGIF(ctx, &out, BoundingBox{South: -60, West: -170, North: 60, East: 170}, 8, 3); err != errRadarTooLarge {
+		t.Errorf("Expected %v, got %v", errRadarTooLarge, err)
+	}
+}
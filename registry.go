@@ -0,0 +1,186 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+var errUnknownUnit = errors.New("unknown unit")
+var errUnitExists = errors.New("unit already registered")
+var errUnitMismatch = errors.New("units measure different quantities")
+
+// Quantity is a physical quantity measured in a MeasurementUnit.
+type Quantity int
+
+// Quantities with registered units.  Their base units, which every
+// MeasurementUnit is defined against, are celsius, m/s, meters and hPa.
+const (
+	QuantityTemperature Quantity = iota
+	QuantitySpeed
+	QuantityDistance
+	QuantityPressure
+)
+
+// MeasurementUnit is a unit values can be converted to.  A value in the
+// unit is Scale times the value in the base unit of its Quantity, plus
+// Offset.  Attached makes FormatValue write the symbol right after the
+// value, like "18°C", instead of after a space.
+type MeasurementUnit struct {
+	Symbol   string
+	Quantity Quantity
+	Scale    float64
+	Offset   float64
+	Attached bool
+}
+
+var (
+	unitsMu sync.RWMutex
+	units   = map[string]MeasurementUnit{}
+)
+
+func init() {
+	for _, u := range []MeasurementUnit{
+		{Symbol: "°C", Quantity: QuantityTemperature, Scale: 1, Attached: true},
+		{Symbol: "°F", Quantity: QuantityTemperature, Scale: 1.8, Offset: 32, Attached: true},
+		{Symbol: "K", Quantity: QuantityTemperature, Scale: 1, Offset: kelvinOffset},
+		{Symbol: "m/s", Quantity: QuantitySpeed, Scale: 1},
+		{Symbol: "km/h", Quantity: QuantitySpeed, Scale: 3.6},
+		{Symbol: "mph", Quantity: QuantitySpeed, Scale: mphPerMeterPerSecond},
+		{Symbol: "kn", Quantity: QuantitySpeed, Scale: knotsPerMeterPerSecond},
+		{Symbol: "kt", Quantity: QuantitySpeed, Scale: knotsPerMeterPerSecond},
+		{Symbol: "m", Quantity: QuantityDistance, Scale: 1},
+		{Symbol: "km", Quantity: QuantityDistance, Scale: 1 / metersPerKilometer},
+		{Symbol: "mi", Quantity: QuantityDistance, Scale: 1 / metersPerStatuteMile},
+		{Symbol: "SM", Quantity: QuantityDistance, Scale: 1 / metersPerStatuteMile},
+		{Symbol: "nmi", Quantity: QuantityDistance, Scale: 1 / metersPerNauticalMile},
+		{Symbol: "NM", Quantity: QuantityDistance, Scale: 1 / metersPerNauticalMile},
+		{Symbol: "hPa", Quantity: QuantityPressure, Scale: 1},
+		{Symbol: "kPa", Quantity: QuantityPressure, Scale: 0.1},
+		{Symbol: "inHg", Quantity: QuantityPressure, Scale: 1 / hectopascalsPerInHg},
+		{Symbol: "mmHg", Quantity: QuantityPressure, Scale: 0.750062},
+	} {
+		units[u.Symbol] = u
+	}
+}
+
+// RegisterUnit adds u to the units known to Convert, FromAPI and
+// FormatValue, e.g. feet per second or Beaufort-free wind in km/h for an
+// application.  Symbols must be unique.
+func RegisterUnit(u MeasurementUnit) error {
+	if u.Symbol == "" || u.Scale == 0 {
+		return errInvalidOption
+	}
+	unitsMu.Lock()
+	defer unitsMu.Unlock()
+	if _, ok := units[u.Symbol]; ok {
+		return errUnitExists
+	}
+	units[u.Symbol] = u
+	return nil
+}
+
+// LookupUnit returns the registered unit with the given symbol.
+func LookupUnit(symbol string) (MeasurementUnit, bool) {
+	unitsMu.RLock()
+	defer unitsMu.RUnlock()
+	u, ok := units[symbol]
+	return u, ok
+}
+
+// Convert converts v from the unit with the symbol from to the unit with
+// the symbol to.  Both must measure the same quantity.
+func Convert(v float64, from, to string) (float64, error) {
+	f, ok := LookupUnit(from)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", errUnknownUnit, from)
+	}
+	t, ok := LookupUnit(to)
+	if !ok {
+		return 0, fmt.Errorf("%w: %s", errUnknownUnit, to)
+	}
+	if f.Quantity != t.Quantity {
+		return 0, errUnitMismatch
+	}
+	return (v-f.Offset)/f.Scale*t.Scale + t.Offset, nil
+}
+
+// apiSymbols maps the values of the Unit field of the results to the
+// symbols of the temperature and speed units OWM returns.  Distances are
+// always in meters and pressures in hPa.
+var apiSymbols = map[string][2]string{
+	"metric":   {"°C", "m/s"},
+	"imperial": {"°F", "mph"},
+	"internal": {"K", "m/s"},
+}
+
+// apiSymbol returns the symbol of the unit OWM returns q in for unit.
+func apiSymbol(q Quantity, unit string) string {
+	switch q {
+	case QuantityDistance:
+		return "m"
+	case QuantityPressure:
+		return "hPa"
+	}
+	s, ok := apiSymbols[unit]
+	if !ok {
+		s = apiSymbols["metric"]
+	}
+	if q == QuantityTemperature {
+		return s[0]
+	}
+	return s[1]
+}
+
+// FromAPI converts v, a value of quantity q as returned by OWM for the
+// given units ("metric", "imperial" or "internal" as stored in the Unit
+// field of the results), to the unit with the symbol to.  This lets
+// every measurement be shown in its own unit, independent of the units
+// the data was requested in.
+func FromAPI(q Quantity, v float64, unit, to string) (float64, error) {
+	return Convert(v, apiSymbol(q, unit), to)
+}
+
+// FormatValue formats v, in the unit with the given symbol, with the
+// given number of decimals, e.g. "18°C" or "12.5 km/h".  The symbol is
+// attached to the value if its registered unit says so; symbols that
+// aren't registered are attached if they start with a degree sign.
+func FormatValue(v float64, symbol string, decimals int) string {
+	attached := strings.HasPrefix(symbol, "°")
+	if u, ok := LookupUnit(symbol); ok {
+		attached = u.Attached
+	}
+	if attached {
+		return fmt.Sprintf("%.*f%s", decimals, v, symbol)
+	}
+	return fmt.Sprintf("%.*f %s", decimals, v, symbol)
+}
+
+// displayValue converts v, a value of quantity q as returned by OWM for
+// unit, to the registered unit with the symbol to and formats it.  An
+// empty to keeps the unit OWM returned.
+func displayValue(q Quantity, v float64, unit, to string, decimals int) (string, error) {
+	from := apiSymbol(q, unit)
+	if to == "" {
+		to = from
+	}
+	c, err := Convert(v, from, to)
+	if err != nil {
+		return "", err
+	}
+	return FormatValue(c, to, decimals), nil
+}
@@ -0,0 +1,140 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		v        float64
+		from, to string
+		want     float64
+	}{
+		{18, "°C", "°F", 64.4},
+		{64.4, "°F", "K", 291.15},
+		{10, "m/s", "km/h", 36},
+		{36, "km/h", "kn", 19.44},
+		{100, "mph", "km/h", 160.93},
+		{10000, "m", "mi", 6.21},
+		{1, "nmi", "km", 1.852},
+		{1013.25, "hPa", "inHg", 29.92},
+		{29.92, "inHg", "mmHg", 759.97},
+	}
+	for _, tt := range tests {
+		got, err := Convert(tt.v, tt.from, tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(got, tt.want) {
+			t.Errorf("Convert(%v, %s, %s) = %v, want %v", tt.v, tt.from, tt.to, got, tt.want)
+		}
+	}
+
+	if _, err := Convert(1, "m/s", "hPa"); err != errUnitMismatch {
+		t.Errorf("Expected %v, got %v", errUnitMismatch, err)
+	}
+	if _, err := Convert(1, "furlong", "m"); !errors.Is(err, errUnknownUnit) {
+		t.Errorf("Expected %v, got %v", errUnknownUnit, err)
+	}
+}
+
+func TestRegisterUnit(t *testing.T) {
+	t.Parallel()
+
+	if err := RegisterUnit(MeasurementUnit{Symbol: "ft/s", Quantity: QuantitySpeed, Scale: 3.28084}); err != nil {
+		t.Fatal(err)
+	}
+	got, err := FromAPI(QuantitySpeed, 22.369, "imperial", "ft/s")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !almostEqual(got, 32.81) {
+		t.Errorf("Expected 32.81 ft/s, got %v", got)
+	}
+
+	if err := RegisterUnit(MeasurementUnit{Symbol: "kn", Quantity: QuantitySpeed, Scale: 2}); err != errUnitExists {
+		t.Errorf("Expected %v, got %v", errUnitExists, err)
+	}
+	if err := RegisterUnit(MeasurementUnit{Symbol: "zero", Quantity: QuantitySpeed}); err != errInvalidOption {
+		t.Errorf("Expected %v, got %v", errInvalidOption, err)
+	}
+}
+
+func TestFromAPI(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		q        Quantity
+		v        float64
+		unit, to string
+		want     float64
+	}{
+		{QuantityTemperature, 300, "internal", "°C", 26.85},
+		{QuantityTemperature, 212, "imperial", "°C", 100},
+		{QuantitySpeed, 10, "metric", "km/h", 36},
+		{QuantityDistance, 10000, "imperial", "km", 10},
+		{QuantityPressure, 1000, "metric", "kPa", 100},
+	}
+	for _, tt := range tests {
+		got, err := FromAPI(tt.q, tt.v, tt.unit, tt.to)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !almostEqual(got, tt.want) {
+			t.Errorf("FromAPI(%v, %v, %s, %s) = %v, want %v", tt.q, tt.v, tt.unit, tt.to, got, tt.want)
+		}
+	}
+}
+
+func TestFormatValue(t *testing.T) {
+	t.Parallel()
+
+	if got := FormatValue(18.04, "°C", 0); got != "18°C" {
+		t.Errorf("Expected 18°C, got %s", got)
+	}
+	if got := FormatValue(12.46, "km/h", 1); got != "12.5 km/h" {
+		t.Errorf("Expected 12.5 km/h, got %s", got)
+	}
+	if err := RegisterUnit(MeasurementUnit{Symbol: "′", Quantity: QuantityDistance, Scale: 3.28084, Attached: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := FormatValue(3, "′", 0); got != "3′" {
+		t.Errorf("Expected the registered unit to be attached, got %s", got)
+	}
+	if got := FormatValue(3, "°Ré", 0); got != "3°Ré" {
+		t.Errorf("Expected unregistered degrees to be attached, got %s", got)
+	}
+}
+
+func TestDisplayValue(t *testing.T) {
+	t.Parallel()
+
+	if got, err := displayValue(QuantitySpeed, 10, "metric", "km/h", 0); err != nil || got != "36 km/h" {
+		t.Errorf("Expected 36 km/h, got %q and %v", got, err)
+	}
+	if got, err := displayValue(QuantityTemperature, 64.4, "imperial", "", 1); err != nil || got != "64.4°F" {
+		t.Errorf("Expected the unit OWM returned, got %q and %v", got, err)
+	}
+	if _, err := displayValue(QuantitySpeed, 10, "metric", "°C", 0); err != errUnitMismatch {
+		t.Errorf("Expected %v, got %v", errUnitMismatch, err)
+	}
+	if _, err := displayValue(QuantitySpeed, 10, "metric", "furlong/fortnight", 0); !errors.Is(err, errUnknownUnit) {
+		t.Errorf("Expected %v, got %v", errUnknownUnit, err)
+	}
+}
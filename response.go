@@ -0,0 +1,125 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+)
+
+// excerptLength is the number of bytes of a response body kept in a
+// DecodeError.
+const excerptLength = 256
+
+// ErrResponseTooLarge is returned when a response body is larger than
+// the configured maximum response size.
+var ErrResponseTooLarge = errors.New("openweathermap: response too large")
+
+// DecodeError is returned when a response body can't be decoded.  It
+// holds the start of the body to help spot what OWM sent.
+type DecodeError struct {
+	Err     error
+	Excerpt string
+}
+
+// Error implements the error interface.
+func (e *DecodeError) Error() string {
+	return fmt.Sprintf("openweathermap: decoding response: %v: %s", e.Err, e.Excerpt)
+}
+
+// Unwrap returns the underlying decoding error.
+func (e *DecodeError) Unwrap() error { return e.Err }
+
+// requestURL returns a copy of the URL response was requested with, minus
+// the API key.
+func requestURL(response *http.Response) *url.URL {
+	var u url.URL
+	if response.Request != nil {
+		u = *response.Request.URL
+		q := u.Query()
+		q.Del("appid")
+		u.RawQuery = q.Encode()
+	}
+	return &u
+}
+
+// readBody reads the body of response, up to the maximum response size.
+func (s *Settings) readBody(response *http.Response) ([]byte, error) {
+	body, err := ioutil.ReadAll(io.LimitReader(response.Body, s.maxResponseSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(body)) > s.maxResponseSize {
+		return nil, fmt.Errorf("%w: %s response exceeds %d bytes", ErrResponseTooLarge, Endpoint(requestURL(response)), s.maxResponseSize)
+	}
+	return body, nil
+}
+
+// decode reads the JSON body of response into v, honoring the decoding
+// settings.
+func (s *Settings) decode(response *http.Response, v interface{}) error {
+	body, err := s.readBody(response)
+	if err != nil {
+		return err
+	}
+
+	if s.raw != nil {
+		s.raw(requestURL(response), body)
+	}
+
+	dec := json.NewDecoder(bytes.NewReader(body))
+	if s.strict {
+		dec.DisallowUnknownFields()
+	}
+	if err := dec.Decode(v); err != nil {
+		return decodeError(err, body)
+	}
+	return nil
+}
+
+// decodeXML reads the XML body of response into v, honoring the
+// decoding settings except strict decoding, which encoding/xml doesn't
+// support.
+func (s *Settings) decodeXML(response *http.Response, v interface{}) error {
+	body, err := s.readBody(response)
+	if err != nil {
+		return err
+	}
+
+	if s.raw != nil {
+		s.raw(requestURL(response), body)
+	}
+
+	if err := xml.Unmarshal(body, v); err != nil {
+		return decodeError(err, body)
+	}
+	return nil
+}
+
+// decodeError returns a DecodeError for err with the start of body.
+func decodeError(err error, body []byte) *DecodeError {
+	excerpt := body
+	if len(excerpt) > excerptLength {
+		excerpt = append(excerpt[:excerptLength:excerptLength], "..."...)
+	}
+	return &DecodeError{Err: err, Excerpt: string(excerpt)}
+}
@@ -0,0 +1,55 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+// Constants of the road surface energy balance used by
+// EstimateRoadSurfaceTemp.
+const (
+	asphaltAbsorptivity = 0.9  // share of solar radiation absorbed
+	clearSkyLongwave    = 60.0 // net longwave loss under a clear sky, W/m²
+	radiativeCoeff      = 5.5  // linearized longwave exchange, W/m²K
+	groundCoeff         = 15.0 // heat conducted into the road body, W/m²K
+)
+
+// EstimateRoadSurfaceTemp returns an ESTIMATE of the road surface
+// temperature, in celsius, for use where the Road Risk API isn't
+// available.  It balances absorbed solar radiation and longwave loss
+// against convective, radiative and ground heat exchange:
+//
+//	air       air temperature in celsius
+//	radiation global horizontal solar radiation in W/m²
+//	windSpeed wind speed in m/s
+//	clouds    cloud cover in percent
+//
+// The model ignores road material, traffic, shading and residual heat
+// from earlier hours.  Expect errors of several degrees; don't use it
+// where a measured or Road Risk API value exists.
+func EstimateRoadSurfaceTemp(air, radiation, windSpeed float64, clouds int) float64 {
+	if radiation < 0 {
+		radiation = 0
+	}
+	if windSpeed < 0 {
+		windSpeed = 0
+	}
+	if clouds < 0 {
+		clouds = 0
+	} else if clouds > 100 {
+		clouds = 100
+	}
+
+	convective := 5.7 + 3.8*windSpeed
+	longwave := clearSkyLongwave * (1 - float64(clouds)/100)
+	return air + (asphaltAbsorptivity*radiation-longwave)/(convective+radiativeCoeff+groundCoeff)
+}
@@ -0,0 +1,41 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import "testing"
+
+func TestEstimateRoadSurfaceTemp(t *testing.T) {
+	t.Parallel()
+
+	sunny := EstimateRoadSurfaceTemp(25, 800, 2, 0)
+	if sunny < 40 || sunny > 50 {
+		t.Errorf("Expected a sunny road around 45C, got %.1f", sunny)
+	}
+	if windy := EstimateRoadSurfaceTemp(25, 800, 10, 0); windy >= sunny {
+		t.Errorf("Expected wind to cool the road below %.1f, got %.1f", sunny, windy)
+	}
+
+	clearNight := EstimateRoadSurfaceTemp(1, 0, 0.5, 0)
+	if clearNight >= 0 {
+		t.Errorf("Expected a clear night to freeze the road, got %.1f", clearNight)
+	}
+	if overcast := EstimateRoadSurfaceTemp(1, 0, 0.5, 100); overcast != 1 {
+		t.Errorf("Expected an overcast night to keep the road at air temperature, got %.1f", overcast)
+	}
+
+	if got := EstimateRoadSurfaceTemp(1, -10, -1, 150); got != 1 {
+		t.Errorf("Expected out of range inputs to be clamped, got %.1f", got)
+	}
+}
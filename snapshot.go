@@ -0,0 +1,71 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// SnapshotCity is the city of a bulk snapshot record.
+type SnapshotCity struct {
+	ID       int         `json:"id"`
+	Name     string      `json:"name"`
+	FindName string      `json:"findname"`
+	Country  string      `json:"country"`
+	Coord    Coordinates `json:"coord"`
+	Zoom     int         `json:"zoom"`
+}
+
+// SnapshotRecord is the current weather of one city in a bulk snapshot
+// file.
+type SnapshotRecord struct {
+	City    SnapshotCity `json:"city"`
+	Time    Timestamp    `json:"time"`
+	Main    Main         `json:"main"`
+	Wind    Wind         `json:"wind"`
+	Clouds  Clouds       `json:"clouds"`
+	Weather []Weather    `json:"weather"`
+	Rain    Rain         `json:"rain"`
+	Snow    Snow         `json:"snow"`
+}
+
+// SnapshotReader reads the records of a bulk snapshot file, such as
+// weather_14.json.gz, which holds the current weather of every city
+// with one JSON record per line.  Records are decoded one at a time, so
+// files of any size are read in constant memory.
+type SnapshotReader struct {
+	d *json.Decoder
+}
+
+// NewSnapshotReader returns a SnapshotReader reading the snapshot from
+// r, which may be gzip compressed.
+func NewSnapshotReader(r io.Reader) (*SnapshotReader, error) {
+	br, err := decompress(r)
+	if err != nil {
+		return nil, err
+	}
+	return &SnapshotReader{d: json.NewDecoder(br)}, nil
+}
+
+// Next returns the next record of the snapshot, or io.EOF after the
+// last one.
+func (s *SnapshotReader) Next() (*SnapshotRecord, error) {
+	rec := &SnapshotRecord{}
+	if err := s.d.Decode(rec); err != nil {
+		return nil, err
+	}
+	return rec, nil
+}
@@ -0,0 +1,79 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"io"
+	"strings"
+	"testing"
+)
+
+const testSnapshot = `{"city":{"id":2950159,"name":"Berlin","findname":"BERLIN","country":"DE","coord":{"lon":13.41053,"lat":52.524368},"zoom":1},"time":1577836800,"main":{"temp":276.25,"pressure":1012,"humidity":86,"temp_min":275.15,"temp_max":277.04},"wind":{"speed":3.6,"deg":240},"clouds":{"all":90},"weather":[{"id":500,"main":"Rain","description":"light rain","icon":"10n"}],"rain":{"1h":0.3}}
+{"city":{"id":2643743,"name":"London","findname":"LONDON","country":"GB","coord":{"lon":-0.12574,"lat":51.50853},"zoom":1},"time":1577836800,"main":{"temp":280.1,"pressure":1030,"humidity":76},"wind":{"speed":2.1,"deg":200},"clouds":{"all":20},"weather":[{"id":801,"main":"Clouds","description":"few clouds","icon":"02n"}]}
+`
+
+func TestSnapshotReader(t *testing.T) {
+	t.Parallel()
+
+	for name, r := range map[string]io.Reader{
+		"json":    strings.NewReader(testSnapshot),
+		"json.gz": gzipped(t, testSnapshot),
+	} {
+		s, err := NewSnapshotReader(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var recs []*SnapshotRecord
+		for {
+			rec, err := s.Next()
+			if err == io.EOF {
+				break
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			recs = append(recs, rec)
+		}
+
+		if len(recs) != 2 {
+			t.Fatalf("%s: expected 2 records, got %d", name, len(recs))
+		}
+		b := recs[0]
+		if b.City.ID != 2950159 || b.City.Country != "DE" || !almostEqual(b.City.Coord.Latitude, 52.524368) || b.Time.Unix() != 1577836800 {
+			t.Errorf("%s: unexpected record %+v", name, b)
+		}
+		if !almostEqual(b.Main.Temp, 276.25) || b.Clouds.All != 90 || !almostEqual(b.Rain.OneH, 0.3) || b.Weather[0].ID != 500 {
+			t.Errorf("%s: unexpected weather %+v", name, b)
+		}
+		if recs[1].City.Name != "London" {
+			t.Errorf("%s: unexpected second record %+v", name, recs[1])
+		}
+	}
+}
+
+func TestSnapshotReaderMalformed(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewSnapshotReader(strings.NewReader(`{"city":{"id":1}}` + "\n{\"city\":"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Next(); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.Next(); err == nil || err == io.EOF {
+		t.Errorf("Expected a decoding error, got %v", err)
+	}
+}
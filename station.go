@@ -16,7 +16,6 @@ package openweathermap
 
 import (
 	"fmt"
-	"net/http"
 	"net/url"
 )
 
@@ -71,7 +70,7 @@ func ConvertToURLValues(data map[string]string) string {
 // SendStationData will send an instance the provided url.Values to the
 // provided URL.
 func SendStationData(data url.Values) {
-	resp, err := http.PostForm(dataPostURL, data)
+	resp, err := defaultClient.PostForm(dataPostURL, data)
 	if err != nil {
 		fmt.Println(err)
 	}
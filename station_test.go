@@ -16,6 +16,7 @@ package openweathermap
 
 import (
 	"reflect"
+	"strconv"
 	"testing"
 )
 
@@ -50,7 +51,7 @@ func TestConvertToURLValues(t *testing.T) {
 	var urlData = make(map[string]string)
 
 	for _, s := range StationDataParameters {
-		urlData[s] = string(count)
+		urlData[s] = strconv.Itoa(count)
 		count++
 	}
 
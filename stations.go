@@ -0,0 +1,265 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+var errStationID = errors.New("station ID required")
+var errInvalidStation = errors.New("station needs an external ID and a name")
+var errInvalidMeasurement = errors.New("measurements need a station ID and a time")
+var errInvalidAggregation = errors.New("aggregation must be m, h or d")
+
+// Station is a personal weather station registered with OWM.
+type Station struct {
+	ID         string    `json:"id"`
+	ExternalID string    `json:"external_id"`
+	Name       string    `json:"name"`
+	Latitude   float64   `json:"latitude"`
+	Longitude  float64   `json:"longitude"`
+	Altitude   float64   `json:"altitude"`
+	Rank       int       `json:"rank"`
+	CreatedAt  time.Time `json:"created_at"`
+	UpdatedAt  time.Time `json:"updated_at"`
+}
+
+// StationParams describes a station to register or update.  ExternalID
+// is the station's own identifier and, like Name, is required.
+type StationParams struct {
+	ExternalID string  `json:"external_id"`
+	Name       string  `json:"name"`
+	Latitude   float64 `json:"latitude"`
+	Longitude  float64 `json:"longitude"`
+	Altitude   float64 `json:"altitude"`
+}
+
+// Measurement holds what a station measured at one time.  Only the
+// values that are set are sent; Float returns a pointer to set them.
+// Units are those of the metric system: °C, m/s, hPa, % and mm.
+type Measurement struct {
+	StationID   string
+	Time        time.Time
+	Temperature *float64
+	Humidity    *float64
+	Pressure    *float64
+	WindSpeed   *float64
+	WindGust    *float64
+	WindDeg     *float64
+	DewPoint    *float64
+	Rain1h      *float64
+	Rain6h      *float64
+	Rain24h     *float64
+	Snow1h      *float64
+	Snow6h      *float64
+	Snow24h     *float64
+}
+
+// Float returns a pointer to v, to set optional values such as those of
+// a Measurement.
+func Float(v float64) *float64 {
+	return &v
+}
+
+// MarshalJSON encodes the measurement the way the Stations API expects
+// it.
+func (m Measurement) MarshalJSON() ([]byte, error) {
+	return json.Marshal(struct {
+		StationID   string   `json:"station_id"`
+		Dt          int64    `json:"dt"`
+		Temperature *float64 `json:"temperature,omitempty"`
+		Humidity    *float64 `json:"humidity,omitempty"`
+		Pressure    *float64 `json:"pressure,omitempty"`
+		WindSpeed   *float64 `json:"wind_speed,omitempty"`
+		WindGust    *float64 `json:"wind_gust,omitempty"`
+		WindDeg     *float64 `json:"wind_deg,omitempty"`
+		DewPoint    *float64 `json:"dew_point,omitempty"`
+		Rain1h      *float64 `json:"rain_1h,omitempty"`
+		Rain6h      *float64 `json:"rain_6h,omitempty"`
+		Rain24h     *float64 `json:"rain_24h,omitempty"`
+		Snow1h      *float64 `json:"snow_1h,omitempty"`
+		Snow6h      *float64 `json:"snow_6h,omitempty"`
+		Snow24h     *float64 `json:"snow_24h,omitempty"`
+	}{
+		m.StationID, m.Time.Unix(), m.Temperature, m.Humidity, m.Pressure,
+		m.WindSpeed, m.WindGust, m.WindDeg, m.DewPoint,
+		m.Rain1h, m.Rain6h, m.Rain24h, m.Snow1h, m.Snow6h, m.Snow24h,
+	})
+}
+
+// Aggregation is the period measurements are aggregated over.
+type Aggregation string
+
+// Aggregation periods of GetMeasurements.
+const (
+	AggregationMinute Aggregation = "m"
+	AggregationHour   Aggregation = "h"
+	AggregationDay    Aggregation = "d"
+)
+
+// MeasurementStats holds the statistics of a value over an aggregation
+// period.  Weight is the number of measurements they're computed from.
+type MeasurementStats struct {
+	Min     float64 `json:"min"`
+	Max     float64 `json:"max"`
+	Average float64 `json:"average"`
+	Weight  int     `json:"weight"`
+}
+
+// AggregatedMeasurement holds the measurements of a station aggregated
+// over one period, which starts at Date.
+type AggregatedMeasurement struct {
+	Type      Aggregation      `json:"type"`
+	Date      Timestamp        `json:"date"`
+	StationID string           `json:"station_id"`
+	Temp      MeasurementStats `json:"temp"`
+	Humidity  MeasurementStats `json:"humidity"`
+	Pressure  MeasurementStats `json:"pressure"`
+	Wind      struct {
+		Deg   float64 `json:"deg"`
+		Speed float64 `json:"speed"`
+	} `json:"wind"`
+	Precipitation struct {
+		Rain float64 `json:"rain"`
+		Snow float64 `json:"snow"`
+	} `json:"precipitation"`
+}
+
+// Stations manages the weather stations of the API key through the
+// Stations API 3.0.
+type Stations struct {
+	c *Client
+}
+
+// Stations returns the Stations service of the Client.
+func (c *Client) Stations() *Stations {
+	return &Stations{c: c}
+}
+
+// url returns the URL of the stations API path, e.g. "/id".
+func (s *Stations) url(path string) string {
+	return s.c.dataURL("stations") + path + "?" + url.Values{"appid": {s.c.key}}.Encode()
+}
+
+// Register registers a new station and returns it with its ID.
+func (s *Stations) Register(ctx context.Context, p StationParams) (*Station, error) {
+	if p.ExternalID == "" || p.Name == "" {
+		return nil, errInvalidStation
+	}
+	st := &Station{}
+	if err := s.c.sendJSON(ctx, http.MethodPost, s.url(""), p, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// List returns the stations registered with the API key.
+func (s *Stations) List(ctx context.Context) ([]Station, error) {
+	var list []Station
+	if err := s.c.sendJSON(ctx, http.MethodGet, s.url(""), nil, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Get returns the station with the given ID.
+func (s *Stations) Get(ctx context.Context, id string) (*Station, error) {
+	if id == "" {
+		return nil, errStationID
+	}
+	st := &Station{}
+	if err := s.c.sendJSON(ctx, http.MethodGet, s.url("/"+url.PathEscape(id)), nil, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Update replaces the description of the station with the given ID and
+// returns the updated station.
+func (s *Stations) Update(ctx context.Context, id string, p StationParams) (*Station, error) {
+	if id == "" {
+		return nil, errStationID
+	}
+	if p.ExternalID == "" || p.Name == "" {
+		return nil, errInvalidStation
+	}
+	st := &Station{}
+	if err := s.c.sendJSON(ctx, http.MethodPut, s.url("/"+url.PathEscape(id)), p, st); err != nil {
+		return nil, err
+	}
+	return st, nil
+}
+
+// Delete deletes the station with the given ID and its measurements.
+func (s *Stations) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return errStationID
+	}
+	return s.c.sendJSON(ctx, http.MethodDelete, s.url("/"+url.PathEscape(id)), nil, nil)
+}
+
+// SendMeasurements sends a batch of measurements, of one or more
+// stations, with a single request.
+func (s *Stations) SendMeasurements(ctx context.Context, measurements []Measurement) error {
+	if len(measurements) == 0 {
+		return errInvalidMeasurement
+	}
+	for _, m := range measurements {
+		if m.StationID == "" || m.Time.IsZero() {
+			return errInvalidMeasurement
+		}
+	}
+	u := s.c.dataURL("measurements") + "?" + url.Values{"appid": {s.c.key}}.Encode()
+	return s.c.sendJSON(ctx, http.MethodPost, u, measurements, nil)
+}
+
+// GetMeasurements returns the measurements of the station with the given
+// ID from from to to, aggregated per agg.  A limit above zero caps the
+// number of periods returned.
+func (s *Stations) GetMeasurements(ctx context.Context, id string, agg Aggregation, from, to time.Time, limit int) ([]AggregatedMeasurement, error) {
+	if id == "" {
+		return nil, errStationID
+	}
+	switch agg {
+	case AggregationMinute, AggregationHour, AggregationDay:
+	default:
+		return nil, errInvalidAggregation
+	}
+	if !from.Before(to) {
+		return nil, errInvalidTimeRange
+	}
+
+	q := url.Values{
+		"appid":      {s.c.key},
+		"station_id": {id},
+		"type":       {string(agg)},
+		"from":       {strconv.FormatInt(from.Unix(), 10)},
+		"to":         {strconv.FormatInt(to.Unix(), 10)},
+	}
+	if limit > 0 {
+		q.Set("limit", strconv.Itoa(limit))
+	}
+
+	var list []AggregatedMeasurement
+	if err := s.c.sendJSON(ctx, http.MethodGet, s.c.dataURL("measurements")+"?"+q.Encode(), nil, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
@@ -0,0 +1,195 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestStations(t *testing.T) {
+	t.Parallel()
+
+	var methods []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		methods = append(methods, r.Method+" "+r.URL.Path)
+		if r.URL.Query().Get("appid") != testKey || r.URL.Query().Get("units") != "" {
+			t.Errorf("unexpected query %s", r.URL.RawQuery)
+		}
+		switch r.Method {
+		case http.MethodPost, http.MethodPut:
+			if r.Header.Get("Content-Type") != "application/json" {
+				t.Errorf("Expected a JSON body, got %s", r.Header.Get("Content-Type"))
+			}
+			var p StationParams
+			if err := json.NewDecoder(r.Body).Decode(&p); err != nil {
+				t.Error(err)
+			}
+			if r.Method == http.MethodPost {
+				w.WriteHeader(http.StatusCreated)
+			}
+			fmt.Fprintf(w, `{"ID":"583436dd9643a9000196b8d6","created_at":"2016-11-22T12:15:25.967Z","updated_at":"2016-11-22T12:15:25.967Z","external_id":%q,"name":%q,"longitude":%v,"latitude":%v,"altitude":%v,"source_type":5}`,
+				p.ExternalID, p.Name, p.Longitude, p.Latitude, p.Altitude)
+		case http.MethodGet:
+			if r.URL.Path == "/data/3.0/stations" {
+				fmt.Fprint(w, `[{"id":"583436dd9643a9000196b8d6","external_id":"SF_TEST001","name":"San Francisco Test Station","longitude":-122.43,"latitude":37.76,"altitude":150,"rank":10}]`)
+				return
+			}
+			fmt.Fprint(w, `{"id":"583436dd9643a9000196b8d6","external_id":"SF_TEST001","name":"San Francisco Test Station"}`)
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	ctx := context.Background()
+	s := c.Stations()
+	p := StationParams{ExternalID: "SF_TEST001", Name: "San Francisco Test Station", Latitude: 37.76, Longitude: -122.43, Altitude: 150}
+	st, err := s.Register(ctx, p)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if st.ID != "583436dd9643a9000196b8d6" || st.Name != p.Name || st.Altitude != 150 || st.CreatedAt.Year() != 2016 {
+		t.Errorf("unexpected station %+v", st)
+	}
+
+	list, err := s.List(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 || list[0].Rank != 10 {
+		t.Errorf("unexpected stations %+v", list)
+	}
+
+	if st, err = s.Get(ctx, st.ID); err != nil || st.ExternalID != "SF_TEST001" {
+		t.Errorf("unexpected station %+v, %v", st, err)
+	}
+
+	p.Name = "Renamed"
+	if st, err = s.Update(ctx, st.ID, p); err != nil || st.Name != "Renamed" {
+		t.Errorf("unexpected station %+v, %v", st, err)
+	}
+
+	if err := s.Delete(ctx, st.ID); err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		"POST /data/3.0/stations",
+		"GET /data/3.0/stations",
+		"GET /data/3.0/stations/583436dd9643a9000196b8d6",
+		"PUT /data/3.0/stations/583436dd9643a9000196b8d6",
+		"DELETE /data/3.0/stations/583436dd9643a9000196b8d6",
+	}
+	if fmt.Sprint(methods) != fmt.Sprint(want) {
+		t.Errorf("Expected requests %v, got %v", want, methods)
+	}
+}
+
+func TestStationsValidation(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	})
+
+	ctx := context.Background()
+	s := c.Stations()
+	if _, err := s.Register(ctx, StationParams{Name: "No external ID"}); err != errInvalidStation {
+		t.Errorf("Expected %v, got %v", errInvalidStation, err)
+	}
+	if _, err := s.Get(ctx, ""); err != errStationID {
+		t.Errorf("Expected %v, got %v", errStationID, err)
+	}
+	if err := s.Delete(ctx, ""); err != errStationID {
+		t.Errorf("Expected %v, got %v", errStationID, err)
+	}
+}
+
+func TestStationsSendMeasurements(t *testing.T) {
+	t.Parallel()
+
+	var body string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/data/3.0/measurements" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		b, _ := ioutil.ReadAll(r.Body)
+		body = string(b)
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	ctx := context.Background()
+	s := c.Stations()
+	batch := []Measurement{
+		{StationID: "583436dd9643a9000196b8d6", Time: time.Unix(1479817340, 0), Temperature: Float(0), Humidity: Float(87), WindSpeed: Float(1.2)},
+		{StationID: "583436dd9643a9000196b8d6", Time: time.Unix(1479817940, 0), Rain1h: Float(2)},
+	}
+	if err := s.SendMeasurements(ctx, batch); err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"station_id":"583436dd9643a9000196b8d6","dt":1479817340,"temperature":0,"humidity":87,"wind_speed":1.2},` +
+		`{"station_id":"583436dd9643a9000196b8d6","dt":1479817940,"rain_1h":2}]`
+	if body != want {
+		t.Errorf("Expected body %s, got %s", want, body)
+	}
+
+	if err := s.SendMeasurements(ctx, nil); err != errInvalidMeasurement {
+		t.Errorf("Expected %v, got %v", errInvalidMeasurement, err)
+	}
+	if err := s.SendMeasurements(ctx, []Measurement{{StationID: "x"}}); err != errInvalidMeasurement {
+		t.Errorf("Expected %v, got %v", errInvalidMeasurement, err)
+	}
+}
+
+func TestStationsGetMeasurements(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet || r.URL.Path != "/data/3.0/measurements" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		want := "appid=" + testKey + "&from=1469700000&limit=100&station_id=583436dd9643a9000196b8d6&to=1469899000&type=h"
+		if r.URL.RawQuery != want {
+			t.Errorf("Expected query %s, got %s", want, r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `[{"type":"h","date":1479816000,"station_id":"583436dd9643a9000196b8d6","temp":{"max":18.7,"min":17.9,"average":18.3,"weight":2},"humidity":{"max":87,"min":85,"average":86,"weight":2},"wind":{"deg":270,"speed":1.2},"pressure":{"min":1021,"max":1021,"average":1021,"weight":2},"precipitation":{"rain":2}}]`)
+	})
+
+	ctx := context.Background()
+	s := c.Stations()
+	from, to := time.Unix(1469700000, 0), time.Unix(1469899000, 0)
+	list, err := s.GetMeasurements(ctx, "583436dd9643a9000196b8d6", AggregationHour, from, to, 100)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list) != 1 {
+		t.Fatalf("Expected 1 period, got %d", len(list))
+	}
+	m := list[0]
+	if m.Type != AggregationHour || m.Temp.Min != 17.9 || m.Temp.Weight != 2 || m.Humidity.Average != 86 || m.Wind.Deg != 270 || m.Precipitation.Rain != 2 {
+		t.Errorf("unexpected measurement %+v", m)
+	}
+
+	if _, err := s.GetMeasurements(ctx, "x", "w", from, to, 0); err != errInvalidAggregation {
+		t.Errorf("Expected %v, got %v", errInvalidAggregation, err)
+	}
+	if _, err := s.GetMeasurements(ctx, "x", AggregationDay, to, from, 0); err != errInvalidTimeRange {
+		t.Errorf("Expected %v, got %v", errInvalidTimeRange, err)
+	}
+}
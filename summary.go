@@ -0,0 +1,254 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+	"time"
+)
+
+var errProfileUnavailable = errors.New("summary profile unavailable")
+
+// Profile selects what a summary emphasizes and which units it uses.
+type Profile string
+
+// Summary profiles accepted by Summarize.
+const (
+	// ProfileStandard describes conditions and temperature in the units
+	// the data was requested in.
+	ProfileStandard Profile = "standard"
+	// ProfileMarine describes wind in knots and Beaufort force, gusts,
+	// visibility in nautical miles and pressure with its trend.
+	ProfileMarine Profile = "marine"
+	// ProfileAviation mixes units the way aviation weather reports do:
+	// wind in knots, visibility in statute miles, temperatures in
+	// celsius and the altimeter setting in inches of mercury.
+	ProfileAviation Profile = "aviation"
+)
+
+// ProfileUnits holds the symbols of the registered units a summary
+// profile shows values in.  An empty symbol keeps the unit the data was
+// requested in.
+type ProfileUnits struct {
+	Temperature string
+	Speed       string
+	Distance    string
+	Pressure    string
+}
+
+var (
+	profilesMu   sync.RWMutex
+	profileUnits = map[Profile]ProfileUnits{
+		ProfileStandard: {},
+		ProfileMarine:   {Temperature: "°C", Speed: "kn", Distance: "NM", Pressure: "hPa"},
+		ProfileAviation: {Temperature: "°C", Speed: "kt", Distance: "SM", Pressure: "inHg"},
+	}
+)
+
+// SetProfileUnits changes the units the summaries of profile show values
+// in, e.g. wind in km/h instead of knots for the marine profile.  The
+// units must be registered, see RegisterUnit, and measure the quantity
+// of their field.
+func SetProfileUnits(profile Profile, u ProfileUnits) error {
+	for _, f := range []struct {
+		q      Quantity
+		symbol string
+	}{{QuantityTemperature, u.Temperature}, {QuantitySpeed, u.Speed}, {QuantityDistance, u.Distance}, {QuantityPressure, u.Pressure}} {
+		if f.symbol == "" {
+			continue
+		}
+		if mu, ok := LookupUnit(f.symbol); !ok {
+			return fmt.Errorf("%w: %s", errUnknownUnit, f.symbol)
+		} else if mu.Quantity != f.q {
+			return errUnitMismatch
+		}
+	}
+
+	profilesMu.Lock()
+	defer profilesMu.Unlock()
+	if _, ok := profileUnits[profile]; !ok {
+		return errProfileUnavailable
+	}
+	profileUnits[profile] = u
+	return nil
+}
+
+// unitsOf returns the units of profile.
+func unitsOf(profile Profile) ProfileUnits {
+	profilesMu.RLock()
+	defer profilesMu.RUnlock()
+	return profileUnits[profile]
+}
+
+// Summarize returns a one line summary of w for the given profile.
+// previous is an earlier reading for the same location, used for the
+// pressure trend of the marine profile; it may be nil.  Values that
+// can't be converted to the units of the profile are returned as an
+// error.
+func Summarize(w *CurrentWeatherData, profile Profile, previous *CurrentWeatherData) (string, error) {
+	switch profile {
+	case ProfileStandard, "":
+		return standardSummary(w, unitsOf(ProfileStandard))
+	case ProfileMarine:
+		return marineSummary(w, previous, unitsOf(ProfileMarine))
+	case ProfileAviation:
+		return aviationSummary(w, unitsOf(ProfileAviation))
+	}
+	return "", errProfileUnavailable
+}
+
+// conditions returns the descriptions of the weather conditions in w.
+func conditions(w *CurrentWeatherData) string {
+	var d []string
+	for _, c := range w.Weather {
+		d = append(d, c.Description)
+	}
+	return strings.Join(d, ", ")
+}
+
+// formatter formats the values of a summary, as returned by OWM for
+// unit, keeping the first conversion error.
+type formatter struct {
+	unit string
+	err  error
+}
+
+// value formats v, a value of quantity q, in the unit with the symbol to,
+// see displayValue.
+func (f *formatter) value(q Quantity, v float64, to string, decimals int) string {
+	s, err := displayValue(q, v, f.unit, to, decimals)
+	if err != nil && f.err == nil {
+		f.err = err
+	}
+	return s
+}
+
+func standardSummary(w *CurrentWeatherData, u ProfileUnits) (string, error) {
+	f := &formatter{unit: w.Unit}
+	s := fmt.Sprintf("%s: %s, %s, humidity %d%%, wind %s %s",
+		w.Name, conditions(w), f.value(QuantityTemperature, w.Main.Temp, u.Temperature, 1), w.Main.Humidity,
+		f.value(QuantitySpeed, w.Wind.Speed, u.Speed, 1), CompassPoint(w.Wind.Deg))
+	if p := w.Precipitation(); p != PrecipitationNone {
+		s += fmt.Sprintf(", likely %s (%s)", p, w.Severity())
+	}
+	if f.err != nil {
+		return "", f.err
+	}
+	return s, nil
+}
+
+func marineSummary(w, previous *CurrentWeatherData, u ProfileUnits) (string, error) {
+	wind, err := FromAPI(QuantitySpeed, w.Wind.Speed, w.Unit, "m/s")
+	if err != nil {
+		return "", err
+	}
+
+	f := &formatter{unit: w.Unit}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: wind %s %s (Beaufort %d)", w.Name,
+		f.value(QuantitySpeed, w.Wind.Speed, u.Speed, 0), CompassPoint(w.Wind.Deg), Beaufort(wind))
+	if w.Wind.Gust > 0 {
+		fmt.Fprintf(&b, ", gusts %s", f.value(QuantitySpeed, w.Wind.Gust, u.Speed, 0))
+	}
+	fmt.Fprintf(&b, ", visibility %s, pressure %s",
+		f.value(QuantityDistance, float64(w.Visibility), u.Distance, 1),
+		f.value(QuantityPressure, w.Main.Pressure, u.Pressure, 0))
+	if previous != nil && previous.Dt.Before(w.Dt.Time) {
+		elapsed := w.Dt.Sub(previous.Dt.Time)
+		fmt.Fprintf(&b, " %s", PressureTrend(previous.Main.Pressure, w.Main.Pressure, elapsed))
+	}
+	if f.err != nil {
+		return "", f.err
+	}
+	return b.String(), nil
+}
+
+func aviationSummary(w *CurrentWeatherData, u ProfileUnits) (string, error) {
+	temp, err := FromAPI(QuantityTemperature, w.Main.Temp, w.Unit, "°C")
+	if err != nil {
+		return "", err
+	}
+	dewPoint, err := Convert(DewPoint(temp, w.Main.Humidity), "°C", apiSymbol(QuantityTemperature, w.Unit))
+	if err != nil {
+		return "", err
+	}
+
+	f := &formatter{unit: w.Unit}
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s: wind %03.0f° at %s", w.Name, w.Wind.Deg, f.value(QuantitySpeed, w.Wind.Speed, u.Speed, 0))
+	if w.Wind.Gust > 0 {
+		fmt.Fprintf(&b, " gusting %s", f.value(QuantitySpeed, w.Wind.Gust, u.Speed, 0))
+	}
+	fmt.Fprintf(&b, ", visibility %s, temperature %s, dew point %s, altimeter %s",
+		f.value(QuantityDistance, float64(w.Visibility), u.Distance, 1),
+		f.value(QuantityTemperature, w.Main.Temp, u.Temperature, 0),
+		f.value(QuantityTemperature, dewPoint, u.Temperature, 0),
+		f.value(QuantityPressure, w.Main.Pressure, u.Pressure, 2))
+	if f.err != nil {
+		return "", f.err
+	}
+	return b.String(), nil
+}
+
+// DualTemperature formats temp, given in the units of unit, in both
+// celsius and fahrenheit, e.g. "18°C / 64°F".
+func DualTemperature(temp float64, unit string) string {
+	return dual(QuantityTemperature, temp, unit, "°C", "°F")
+}
+
+// DualSpeed formats speed, given in the units of unit, in both m/s and
+// miles per hour, e.g. "5 m/s / 11 mph".
+func DualSpeed(speed float64, unit string) string {
+	return dual(QuantitySpeed, speed, unit, "m/s", "mph")
+}
+
+// dual formats v, a value of quantity q in the units of unit, in the
+// units with the symbols a and b.
+func dual(q Quantity, v float64, unit, a, b string) string {
+	va, _ := FromAPI(q, v, unit, a)
+	vb, _ := FromAPI(q, v, unit, b)
+	return FormatValue(va, a, 0) + " / " + FormatValue(vb, b, 0)
+}
+
+// PressureTrend describes the change from the earlier to the later
+// pressure reading, in hPa, taken elapsed apart, using the terms of
+// marine forecasts for the change over three hours: "steady", "rising
+// slowly", "rising", "rising quickly" or "rising very rapidly" and the
+// same for falling.
+func PressureTrend(earlier, later float64, elapsed time.Duration) string {
+	if elapsed <= 0 {
+		return "steady"
+	}
+	change := (later - earlier) / elapsed.Hours() * 3
+
+	direction := "rising"
+	if change < 0 {
+		direction = "falling"
+	}
+	switch c := math.Abs(change); {
+	case c < 0.1:
+		return "steady"
+	case c <= 1.5:
+		return direction + " slowly"
+	case c <= 3.5:
+		return direction
+	case c <= 6:
+		return direction + " quickly"
+	}
+	return direction + " very rapidly"
+}
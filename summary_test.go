@@ -0,0 +1,171 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSummarize(t *testing.T) {
+	t.Parallel()
+
+	w := &CurrentWeatherData{
+		Name:       "Cowes",
+		Weather:    []Weather{{Description: "light rain"}},
+		Main:       Main{Temp: 14.2, Pressure: 1008, Humidity: 87},
+		Visibility: 9000,
+		Wind:       Wind{Speed: 8.2, Deg: 225, Gust: 12.5},
+		Dt:         TimestampFromUnix(1600010800),
+		Unit:       "metric",
+	}
+	previous := &CurrentWeatherData{Main: Main{Pressure: 1011}, Dt: TimestampFromUnix(1600000000)}
+
+	tests := []struct {
+		profile  Profile
+		previous *CurrentWeatherData
+		want     string
+	}{
+		{ProfileStandard, nil, "Cowes: light rain, 14.2°C, humidity 87%, wind 8.2 m/s SW"},
+		{ProfileMarine, nil, "Cowes: wind 16 kn SW (Beaufort 5), gusts 24 kn, visibility 4.9 NM, pressure 1008 hPa"},
+		{ProfileMarine, previous, "Cowes: wind 16 kn SW (Beaufort 5), gusts 24 kn, visibility 4.9 NM, pressure 1008 hPa falling"},
+		{ProfileAviation, nil, "Cowes: wind 225° at 16 kt gusting 24 kt, visibility 5.6 SM, temperature 14°C, dew point 12°C, altimeter 29.77 inHg"},
+	}
+	for _, tt := range tests {
+		got, err := Summarize(w, tt.profile, tt.previous)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, got)
+		}
+	}
+
+	if _, err := Summarize(w, "pirate", nil); err != errProfileUnavailable {
+		t.Errorf("Expected %v, got %v", errProfileUnavailable, err)
+	}
+
+	w.Weather = []Weather{{ID: 500, Description: "light rain"}}
+	w.Main.Temp = -1
+	got, err := Summarize(w, ProfileStandard, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Cowes: light rain, -1.0°C, humidity 87%, wind 8.2 m/s SW, likely freezing rain (heavy)"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+// TestSetProfileUnits isn't parallel, as it changes the units of the
+// marine profile.
+func TestSetProfileUnits(t *testing.T) {
+	defaults := unitsOf(ProfileMarine)
+	defer SetProfileUnits(ProfileMarine, defaults)
+
+	if err := SetProfileUnits(ProfileMarine, ProfileUnits{Speed: "km/h", Distance: "km"}); err != nil {
+		t.Fatal(err)
+	}
+	w := &CurrentWeatherData{Name: "Kiel", Main: Main{Pressure: 1012}, Visibility: 8000, Wind: Wind{Speed: 10, Deg: 270}, Unit: "metric"}
+	got, err := Summarize(w, ProfileMarine, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Kiel: wind 36 km/h W (Beaufort 5), visibility 8.0 km, pressure 1012 hPa"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+
+	if err := SetProfileUnits(ProfileMarine, ProfileUnits{Speed: "hPa"}); err != errUnitMismatch {
+		t.Errorf("Expected %v, got %v", errUnitMismatch, err)
+	}
+	if err := SetProfileUnits(ProfileMarine, ProfileUnits{Speed: "furlong/fortnight"}); !errors.Is(err, errUnknownUnit) {
+		t.Errorf("Expected %v, got %v", errUnknownUnit, err)
+	}
+	if err := SetProfileUnits("pirate", ProfileUnits{}); err != errProfileUnavailable {
+		t.Errorf("Expected %v, got %v", errProfileUnavailable, err)
+	}
+}
+
+func TestSummarizeMarineImperial(t *testing.T) {
+	t.Parallel()
+
+	w := &CurrentWeatherData{Name: "Annapolis", Wind: Wind{Speed: 11.5, Deg: 10}, Unit: "imperial"}
+	got, err := Summarize(w, ProfileMarine, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Annapolis: wind 10 kn N (Beaufort 3), visibility 0.0 NM, pressure 0 hPa"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestSummarizeAviationImperial(t *testing.T) {
+	t.Parallel()
+
+	w := &CurrentWeatherData{
+		Name:       "Denver",
+		Main:       Main{Temp: 64.4, Pressure: 1013.25, Humidity: 30},
+		Visibility: 10000,
+		Wind:       Wind{Speed: 5.75, Deg: 40},
+		Unit:       "imperial",
+	}
+	got, err := Summarize(w, ProfileAviation, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "Denver: wind 040° at 5 kt, visibility 6.2 SM, temperature 18°C, dew point 0°C, altimeter 29.92 inHg"; got != want {
+		t.Errorf("Expected %q, got %q", want, got)
+	}
+}
+
+func TestDualUnits(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		got, want string
+	}{
+		{DualTemperature(18, "metric"), "18°C / 64°F"},
+		{DualTemperature(64.4, "imperial"), "18°C / 64°F"},
+		{DualTemperature(291.15, "internal"), "18°C / 64°F"},
+		{DualSpeed(5, "metric"), "5 m/s / 11 mph"},
+		{DualSpeed(11.18, "imperial"), "5 m/s / 11 mph"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, tt.got)
+		}
+	}
+}
+
+func TestPressureTrend(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		earlier, later float64
+		elapsed        time.Duration
+		want           string
+	}{
+		{1013, 1013, 3 * time.Hour, "steady"},
+		{1013, 1014, 3 * time.Hour, "rising slowly"},
+		{1013, 1010, 3 * time.Hour, "falling"},
+		{1013, 1010, time.Hour, "falling very rapidly"},
+		{1000, 1005, 3 * time.Hour, "rising quickly"},
+		{1000, 1005, 0, "steady"},
+	}
+	for _, tt := range tests {
+		if got := PressureTrend(tt.earlier, tt.later, tt.elapsed); got != tt.want {
+			t.Errorf("PressureTrend(%v, %v, %v) = %s, want %s", tt.earlier, tt.later, tt.elapsed, got, tt.want)
+		}
+	}
+}
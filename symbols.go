@@ -0,0 +1,129 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ConditionSymbols holds the symbols frontends use to show a weather
+// condition.
+type ConditionSymbols struct {
+	// Emoji is the Unicode emoji, e.g. "🌧️".
+	Emoji string
+	// NerdFont is the name of the Nerd Font glyph, e.g.
+	// "nf-weather-rain", as listed in the Nerd Fonts cheat sheet.
+	NerdFont string
+	// WeatherIcons holds the CSS classes of the Weather Icons font,
+	// e.g. "wi wi-owm-day-500".
+	WeatherIcons string
+	// Text is the emoji without the emoji presentation selector, e.g.
+	// "☀" for "☀️", which terminals draw as a one column symbol
+	// where they have one.
+	Text string
+}
+
+// symbolRange maps the condition IDs from, to (inclusive) to their
+// emoji and Nerd Font glyph names, for day and night.
+type symbolRange struct {
+	from, to             int
+	dayEmoji, nightEmoji string
+	dayGlyph, nightGlyph string
+}
+
+// conditionSymbols is the single table of condition symbols, ordered by
+// condition ID.
+var conditionSymbols = []symbolRange{
+	{200, 232, "⛈️", "⛈️", "nf-weather-thunderstorm", "nf-weather-thunderstorm"},
+	{300, 321, "🌦️", "🌧️", "nf-weather-day_sprinkle", "nf-weather-night_alt_sprinkle"},
+	{500, 504, "🌧️", "🌧️", "nf-weather-rain", "nf-weather-rain"},
+	{511, 511, "🌨️", "🌨️", "nf-weather-rain_mix", "nf-weather-rain_mix"},
+	{520, 531, "🌦️", "🌧️", "nf-weather-showers", "nf-weather-showers"},
+	{600, 602, "❄️", "❄️", "nf-weather-snow", "nf-weather-snow"},
+	{611, 613, "🌨️", "🌨️", "nf-weather-sleet", "nf-weather-sleet"},
+	{615, 616, "🌨️", "🌨️", "nf-weather-rain_mix", "nf-weather-rain_mix"},
+	{620, 622, "🌨️", "🌨️", "nf-weather-snow", "nf-weather-snow"},
+	{701, 701, "🌫️", "🌫️", "nf-weather-fog", "nf-weather-fog"},
+	{711, 711, "💨", "💨", "nf-weather-smoke", "nf-weather-smoke"},
+	{721, 721, "🌫️", "🌫️", "nf-weather-day_haze", "nf-weather-fog"},
+	{731, 731, "💨", "💨", "nf-weather-dust", "nf-weather-dust"},
+	{741, 741, "🌫️", "🌫️", "nf-weather-fog", "nf-weather-fog"},
+	{751, 761, "💨", "💨", "nf-weather-dust", "nf-weather-dust"},
+	{762, 762, "🌋", "🌋", "nf-weather-volcano", "nf-weather-volcano"},
+	{771, 771, "💨", "💨", "nf-weather-strong_wind", "nf-weather-strong_wind"},
+	{781, 781, "🌪️", "🌪️", "nf-weather-tornado", "nf-weather-tornado"},
+	{800, 800, "☀️", "🌙", "nf-weather-day_sunny", "nf-weather-night_clear"},
+	{801, 801, "🌤️", "☁️", "nf-weather-day_cloudy", "nf-weather-night_alt_cloudy"},
+	{802, 802, "⛅", "☁️", "nf-weather-day_cloudy", "nf-weather-night_alt_cloudy"},
+	{803, 804, "☁️", "☁️", "nf-weather-cloudy", "nf-weather-cloudy"},
+}
+
+// unknownSymbols is returned for condition IDs missing from the table.
+var unknownSymbols = ConditionSymbols{Emoji: "❓", NerdFont: "nf-weather-na", WeatherIcons: "wi wi-na", Text: "?"}
+
+// iconConditions maps the icon codes, without the day or night suffix,
+// to the condition they stand for.
+var iconConditions = map[string]ConditionID{
+	"01": ConditionClear,
+	"02": ConditionFewClouds,
+	"03": ConditionScatteredClouds,
+	"04": ConditionOvercastClouds,
+	"09": ConditionShowerRain,
+	"10": ConditionLightRain,
+	"11": ConditionThunderstorm,
+	"13": ConditionSnow,
+	"50": ConditionMist,
+}
+
+// emojiPresentation is the variation selector that asks for the emoji
+// rendering of a symbol.
+const emojiPresentation = "\ufe0f"
+
+// Symbols returns the symbols for the weather condition ID conditionID,
+// in their night variant if night is true.
+func Symbols(conditionID int, night bool) ConditionSymbols {
+	for _, r := range conditionSymbols {
+		if conditionID < r.from || conditionID > r.to {
+			continue
+		}
+		s := ConditionSymbols{Emoji: r.dayEmoji, NerdFont: r.dayGlyph, WeatherIcons: fmt.Sprintf("wi wi-owm-day-%d", conditionID)}
+		if night {
+			s = ConditionSymbols{Emoji: r.nightEmoji, NerdFont: r.nightGlyph, WeatherIcons: fmt.Sprintf("wi wi-owm-night-%d", conditionID)}
+		}
+		s.Text = strings.Replace(s.Emoji, emojiPresentation, "", -1)
+		return s
+	}
+	return unknownSymbols
+}
+
+// Symbols returns the symbols for the condition, using the night variant
+// when its icon is a night icon, e.g. "01n".
+func (w Weather) Symbols() ConditionSymbols {
+	return Symbols(w.ID, strings.HasSuffix(w.Icon, "n"))
+}
+
+// IconSymbols returns the symbols for an icon code, e.g. "10n", in the
+// night variant for night icons.  Icons stand for a group of conditions
+// and get the symbols of its most common one.
+func IconSymbols(icon string) ConditionSymbols {
+	if len(icon) != 3 || (icon[2] != 'd' && icon[2] != 'n') {
+		return unknownSymbols
+	}
+	id, ok := iconConditions[icon[:2]]
+	if !ok {
+		return unknownSymbols
+	}
+	return Symbols(int(id), icon[2] == 'n')
+}
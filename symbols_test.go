@@ -0,0 +1,95 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import "testing"
+
+func TestSymbols(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		id    int
+		night bool
+		want  ConditionSymbols
+	}{
+		{800, false, ConditionSymbols{"☀️", "nf-weather-day_sunny", "wi wi-owm-day-800", "☀"}},
+		{800, true, ConditionSymbols{"🌙", "nf-weather-night_clear", "wi wi-owm-night-800", "🌙"}},
+		{502, false, ConditionSymbols{"🌧️", "nf-weather-rain", "wi wi-owm-day-502", "🌧"}},
+		{211, true, ConditionSymbols{"⛈️", "nf-weather-thunderstorm", "wi wi-owm-night-211", "⛈"}},
+		{781, false, ConditionSymbols{"🌪️", "nf-weather-tornado", "wi wi-owm-day-781", "🌪"}},
+		{999, false, unknownSymbols},
+	}
+
+	for _, tt := range tests {
+		if got := Symbols(tt.id, tt.night); got != tt.want {
+			t.Errorf("Symbols(%d, %v): expected %+v, got %+v", tt.id, tt.night, tt.want, got)
+		}
+	}
+}
+
+func TestSymbolsCoverConditions(t *testing.T) {
+	t.Parallel()
+
+	groups := [][]*ConditionData{
+		ThunderstormConditions,
+		DrizzleConditions,
+		RainConditions,
+		SnowConditions,
+		AtmosphereConditions,
+		CloudConditions,
+	}
+	for _, conds := range groups {
+		for _, c := range conds {
+			if Symbols(c.ID, false) == unknownSymbols {
+				t.Errorf("No symbols for condition %d (%s)", c.ID, c.Meaning)
+			}
+		}
+	}
+}
+
+func TestWeatherSymbols(t *testing.T) {
+	t.Parallel()
+
+	w := Weather{ID: 801, Icon: "02n"}
+	if got := w.Symbols(); got.Emoji != "☁️" || got.WeatherIcons != "wi wi-owm-night-801" {
+		t.Errorf("unexpected night symbols %+v", got)
+	}
+	w.Icon = "02d"
+	if got := w.Symbols(); got.Emoji != "🌤️" || got.WeatherIcons != "wi wi-owm-day-801" {
+		t.Errorf("unexpected day symbols %+v", got)
+	}
+}
+
+func TestIconSymbols(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]string{
+		"01d": "☀️",
+		"01n": "🌙",
+		"04n": "☁️",
+		"11d": "⛈️",
+		"13n": "❄️",
+		"50d": "🌫️",
+	}
+	for icon, want := range tests {
+		if got := IconSymbols(icon).Emoji; got != want {
+			t.Errorf("IconSymbols(%s): expected %s, got %s", icon, want, got)
+		}
+	}
+	for _, icon := range []string{"", "01", "01x", "12d", "01d.png"} {
+		if got := IconSymbols(icon); got != unknownSymbols {
+			t.Errorf("IconSymbols(%q): expected the unknown symbols, got %+v", icon, got)
+		}
+	}
+}
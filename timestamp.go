@@ -0,0 +1,121 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"bytes"
+	"encoding/json"
+	"strconv"
+	"time"
+)
+
+// Timestamp is a time the API sends as Unix seconds, such as dt,
+// sunrise and sunset.  It embeds the time.Time, in UTC, so its methods
+// can be called directly.  0, which the API sends for events that don't
+// happen, such as a moonrise on some days, is decoded as the zero time.
+type Timestamp struct {
+	time.Time
+}
+
+// TimestampFromUnix returns the Timestamp of sec Unix seconds; 0 is the
+// zero Timestamp.
+func TimestampFromUnix(sec int64) Timestamp {
+	if sec == 0 {
+		return Timestamp{}
+	}
+	return Timestamp{time.Unix(sec, 0).UTC()}
+}
+
+// UnixSeconds returns the Timestamp as Unix seconds, 0 for the zero
+// Timestamp.
+func (t Timestamp) UnixSeconds() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// UnmarshalJSON decodes a number of Unix seconds.
+func (t *Timestamp) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*t = Timestamp{}
+		return nil
+	}
+	var sec json.Number
+	if err := json.Unmarshal(b, &sec); err != nil {
+		return err
+	}
+	n, err := strconv.ParseFloat(string(sec), 64)
+	if err != nil {
+		return err
+	}
+	*t = TimestampFromUnix(int64(n))
+	return nil
+}
+
+// MarshalJSON encodes the Timestamp as Unix seconds, the way the API
+// does.
+func (t Timestamp) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, t.UnixSeconds(), 10), nil
+}
+
+// MilliTimestamp is a time the API sends as Unix milliseconds, such as
+// the times of trigger alerts.  Like Timestamp, it embeds the time.Time,
+// in UTC, and 0 is decoded as the zero time.
+type MilliTimestamp struct {
+	time.Time
+}
+
+// MilliTimestampFromUnix returns the MilliTimestamp of ms Unix
+// milliseconds; 0 is the zero MilliTimestamp.
+func MilliTimestampFromUnix(ms int64) MilliTimestamp {
+	if ms == 0 {
+		return MilliTimestamp{}
+	}
+	return MilliTimestamp{time.Unix(ms/1000, ms%1000*int64(time.Millisecond)).UTC()}
+}
+
+// UnixMillis returns the MilliTimestamp as Unix milliseconds, 0 for the
+// zero MilliTimestamp.
+func (t MilliTimestamp) UnixMillis() int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// UnmarshalJSON decodes a number of Unix milliseconds.
+func (t *MilliTimestamp) UnmarshalJSON(b []byte) error {
+	if bytes.Equal(b, []byte("null")) {
+		*t = MilliTimestamp{}
+		return nil
+	}
+	var ms json.Number
+	if err := json.Unmarshal(b, &ms); err != nil {
+		return err
+	}
+	n, err := strconv.ParseFloat(string(ms), 64)
+	if err != nil {
+		return err
+	}
+	*t = MilliTimestampFromUnix(int64(n))
+	return nil
+}
+
+// MarshalJSON encodes the MilliTimestamp as Unix milliseconds, the way
+// the API does.
+func (t MilliTimestamp) MarshalJSON() ([]byte, error) {
+	return strconv.AppendInt(nil, t.UnixMillis(), 10), nil
+}
@@ -0,0 +1,89 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestTimestampJSON(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		Dt       Timestamp `json:"dt"`
+		Moonrise Timestamp `json:"moonrise"`
+		Float    Timestamp `json:"float"`
+		Null     Timestamp `json:"null"`
+	}
+	if err := json.Unmarshal([]byte(`{"dt":1600000000,"moonrise":0,"float":1600000000.0,"null":null}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2020, time.September, 13, 12, 26, 40, 0, time.UTC)
+	if !v.Dt.Equal(want) || v.Dt.Location() != time.UTC || !v.Float.Equal(want) {
+		t.Errorf("Expected %v, got %v and %v", want, v.Dt, v.Float)
+	}
+	if !v.Moonrise.IsZero() || !v.Null.IsZero() {
+		t.Errorf("Expected zero times for 0 and null, got %v and %v", v.Moonrise, v.Null)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"dt":1600000000,"moonrise":0,"float":1600000000,"null":0}` {
+		t.Errorf("unexpected encoding %s", b)
+	}
+
+	if err := json.Unmarshal([]byte(`{"dt":"yesterday"}`), &v); err == nil {
+		t.Error("Expected an error for a string")
+	}
+}
+
+func TestTimestampFromUnix(t *testing.T) {
+	t.Parallel()
+
+	if ts := TimestampFromUnix(0); !ts.IsZero() || ts.UnixSeconds() != 0 {
+		t.Errorf("Expected the zero Timestamp, got %v", ts)
+	}
+	if ts := TimestampFromUnix(1600000000); ts.UnixSeconds() != 1600000000 || ts.Year() != 2020 {
+		t.Errorf("unexpected Timestamp %v", ts)
+	}
+}
+
+func TestMilliTimestampJSON(t *testing.T) {
+	t.Parallel()
+
+	var v struct {
+		Date MilliTimestamp `json:"date"`
+		Zero MilliTimestamp `json:"zero"`
+	}
+	if err := json.Unmarshal([]byte(`{"date":1481802090232,"zero":0}`), &v); err != nil {
+		t.Fatal(err)
+	}
+	want := time.Date(2016, time.December, 15, 11, 41, 30, 232000000, time.UTC)
+	if !v.Date.Equal(want) || v.Date.Location() != time.UTC || !v.Zero.IsZero() {
+		t.Errorf("Expected %v and the zero time, got %v and %v", want, v.Date, v.Zero)
+	}
+
+	b, err := json.Marshal(v)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(b) != `{"date":1481802090232,"zero":0}` {
+		t.Errorf("unexpected encoding %s", b)
+	}
+}
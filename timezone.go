@@ -0,0 +1,91 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"fmt"
+	"time"
+)
+
+// fixedZone returns a location offset seconds east of UTC, named after
+// the offset, e.g. "UTC+05:30".
+func fixedZone(offset int) *time.Location {
+	sign, abs := '+', offset
+	if offset < 0 {
+		sign, abs = '-', -offset
+	}
+	return time.FixedZone(fmt.Sprintf("UTC%c%02d:%02d", sign, abs/3600, abs%3600/60), offset)
+}
+
+// zone returns the IANA location called name or, if it's empty or unknown
+// to the system, a fixed zone offset seconds east of UTC.
+func zone(name string, offset int) *time.Location {
+	if name != "" {
+		if loc, err := time.LoadLocation(name); err == nil {
+			return loc
+		}
+	}
+	return fixedZone(offset)
+}
+
+// Location returns the time zone of the location, fixed at the offset
+// from UTC the API reported.
+func (w *CurrentWeatherData) Location() *time.Location {
+	return fixedZone(w.Timezone)
+}
+
+// LocalTime returns the time of the data in the location's time zone.
+// Sunrise and sunset convert the same way, e.g.
+// w.Sys.Sunrise.In(w.Location()).
+func (w *CurrentWeatherData) LocalTime() time.Time {
+	return w.Dt.In(w.Location())
+}
+
+// Location returns the time zone of the city, fixed at the offset from
+// UTC the API reported.
+func (c *City) Location() *time.Location {
+	return fixedZone(c.Timezone)
+}
+
+// Location returns the time zone of the record, fixed at its offset from
+// UTC.
+func (r *BulkRecord) Location() *time.Location {
+	return fixedZone(r.Timezone)
+}
+
+// LocalTime returns the time of the record in its time zone.
+func (r *BulkRecord) LocalTime() time.Time {
+	return r.Dt.In(r.Location())
+}
+
+// Location returns the time zone of the location.  The IANA zone is
+// used when the system knows it, so daylight saving changes within the
+// forecast are accounted for; otherwise the zone is fixed at the current
+// offset from UTC.
+func (o *OneCallData) Location() *time.Location {
+	return zone(o.Timezone, o.TimezoneOffset)
+}
+
+// LocalTime returns the time of the current weather in the location's
+// time zone.
+func (o *OneCallData) LocalTime() time.Time {
+	return o.Current.Dt.In(o.Location())
+}
+
+// Location returns the time zone of the location, see
+// OneCallData.Location.
+func (o *OneCallTimemachineData) Location() *time.Location {
+	return zone(o.Timezone, o.TimezoneOffset)
+}
@@ -0,0 +1,85 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFixedZone(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		offset int
+		name   string
+	}{
+		{0, "UTC+00:00"},
+		{19800, "UTC+05:30"},
+		{-14400, "UTC-04:00"},
+		{-9000, "UTC-02:30"},
+	}
+	for _, tt := range tests {
+		name, offset := time.Unix(0, 0).In(fixedZone(tt.offset)).Zone()
+		if name != tt.name || offset != tt.offset {
+			t.Errorf("fixedZone(%d): expected %s, got %s (%d)", tt.offset, tt.name, name, offset)
+		}
+	}
+}
+
+func TestCurrentLocalTime(t *testing.T) {
+	t.Parallel()
+
+	w := &CurrentWeatherData{Dt: TimestampFromUnix(1600000000), Timezone: 19800}
+	lt := w.LocalTime()
+	if !lt.Equal(w.Dt.Time) {
+		t.Errorf("Expected the same instant, got %v", lt)
+	}
+	if lt.Hour() != 17 || lt.Minute() != 56 {
+		t.Errorf("Expected 17:56 local time, got %v", lt)
+	}
+
+	r := &BulkRecord{Timezone: -18000}
+	r.Dt = TimestampFromUnix(1600000000)
+	if h := r.LocalTime().Hour(); h != 7 {
+		t.Errorf("Expected 7 o'clock, got %d", h)
+	}
+}
+
+func TestOneCallLocation(t *testing.T) {
+	t.Parallel()
+
+	o := &OneCallData{Timezone: "Nowhere/Atlantis", TimezoneOffset: 3600}
+	o.Current.Dt = TimestampFromUnix(1600000000)
+	if name, offset := o.LocalTime().Zone(); name != "UTC+01:00" || offset != 3600 {
+		t.Errorf("Expected the fixed offset, got %s (%d)", name, offset)
+	}
+
+	want, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Skip("no time zone database:", err)
+	}
+	o.Timezone = "Europe/Berlin"
+	if loc := o.Location(); loc.String() != want.String() {
+		t.Errorf("Expected %v, got %v", want, loc)
+	}
+	// Summer time in September, winter time in January.
+	if _, offset := o.LocalTime().Zone(); offset != 7200 {
+		t.Errorf("Expected CEST, got offset %d", offset)
+	}
+	if _, offset := time.Unix(1578000000, 0).In(o.Location()).Zone(); offset != 3600 {
+		t.Errorf("Expected CET, got offset %d", offset)
+	}
+}
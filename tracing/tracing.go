@@ -0,0 +1,108 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+// Package tracing provides optional OpenTelemetry instrumentation for the
+// openweathermap package.  Every request sent through its Transport is
+// wrapped in a client span that is a child of the span found in the
+// request's context.  Requests retried by the Client, e.g. with another
+// key of owm.WithAPIKeys, get a span per attempt, numbered by the
+// owm.retry_attempt attribute.
+//
+//	hc := &http.Client{Transport: tracing.Transport(owm.DefaultTransport())}
+//	c, err := owm.NewClient("F", "EN", apiKey, owm.WithHttpClient(hc))
+package tracing
+
+import (
+	"net/http"
+	"strconv"
+
+	owm "github.com/briandowns/openweathermap"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// instrumentationName identifies the tracer used by this package.
+const instrumentationName = "github.com/briandowns/openweathermap/tracing"
+
+// locationParameters are the query parameters recorded as span
+// attributes.  The API key (appid) is deliberately not among them.
+var locationParameters = []string{"q", "id", "zip", "lat", "lon"}
+
+// Option configures the Transport.
+type Option func(t *transport)
+
+// WithTracerProvider sets the TracerProvider used to create spans.
+// Defaults to the global provider.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(t *transport) {
+		t.tracer = tp.Tracer(instrumentationName)
+	}
+}
+
+// Transport returns an http.RoundTripper that wraps every request in a
+// span before handing it to next.  If next is nil, the shared transport
+// of the openweathermap package, owm.DefaultTransport, is used.
+func Transport(next http.RoundTripper, options ...Option) http.RoundTripper {
+	if next == nil {
+		next = owm.DefaultTransport()
+	}
+	t := &transport{
+		next:   next,
+		tracer: otel.GetTracerProvider().Tracer(instrumentationName),
+	}
+	for _, option := range options {
+		option(t)
+	}
+	return t
+}
+
+type transport struct {
+	next   http.RoundTripper
+	tracer trace.Tracer
+}
+
+// RoundTrip implements http.RoundTripper.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ep := owm.Endpoint(req.URL)
+
+	ctx, span := t.tracer.Start(req.Context(), "owm."+ep, trace.WithSpanKind(trace.SpanKindClient))
+	defer span.End()
+
+	span.SetAttributes(
+		attribute.String("owm.endpoint", ep),
+		attribute.String("http.method", req.Method),
+		attribute.Int("owm.retry_attempt", owm.RetryAttempt(req.Context())),
+	)
+	q := req.URL.Query()
+	for _, p := range locationParameters {
+		if v := q.Get(p); v != "" {
+			span.SetAttributes(attribute.String("owm.location."+p, v))
+		}
+	}
+
+	resp, err := t.next.RoundTrip(req.WithContext(ctx))
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+		return nil, err
+	}
+
+	span.SetAttributes(attribute.Int("http.status_code", resp.StatusCode))
+	if resp.StatusCode >= http.StatusBadRequest {
+		span.SetStatus(codes.Error, strconv.Itoa(resp.StatusCode)+" "+http.StatusText(resp.StatusCode))
+	}
+	return resp, nil
+}
@@ -0,0 +1,151 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package tracing
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	owm "github.com/briandowns/openweathermap"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+)
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) { return f(r) }
+
+// recorder is a minimal TracerProvider keeping every span it starts.
+type recorder struct {
+	spans []*recordedSpan
+}
+
+func (r *recorder) Tracer(string, ...trace.TracerOption) trace.Tracer { return r }
+
+func (r *recorder) Start(ctx context.Context, name string, _ ...trace.SpanStartOption) (context.Context, trace.Span) {
+	s := &recordedSpan{
+		Span:  trace.SpanFromContext(ctx),
+		name:  name,
+		attrs: map[attribute.Key]attribute.Value{},
+	}
+	r.spans = append(r.spans, s)
+	return trace.ContextWithSpan(ctx, s), s
+}
+
+type recordedSpan struct {
+	trace.Span
+	name   string
+	attrs  map[attribute.Key]attribute.Value
+	status codes.Code
+	ended  bool
+}
+
+func (s *recordedSpan) End(...trace.SpanEndOption)              { s.ended = true }
+func (s *recordedSpan) SetStatus(c codes.Code, _ string)        { s.status = c }
+func (s *recordedSpan) RecordError(error, ...trace.EventOption) {}
+func (s *recordedSpan) SetAttributes(kv ...attribute.KeyValue) {
+	for _, a := range kv {
+		s.attrs[a.Key] = a.Value
+	}
+}
+
+func TestTransport(t *testing.T) {
+	t.Parallel()
+
+	tp := &recorder{}
+
+	next := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		if _, ok := trace.SpanFromContext(r.Context()).(*recordedSpan); !ok {
+			t.Error("span not propagated through the request context")
+		}
+		rec := httptest.NewRecorder()
+		rec.WriteHeader(http.StatusNotFound)
+		return rec.Result(), nil
+	})
+	hc := &http.Client{Transport: Transport(next, WithTracerProvider(tp))}
+
+	resp, err := hc.Get("http://api.openweathermap.org/data/2.5/weather?appid=secret&q=Philadelphia")
+	if err != nil {
+		t.Fatal(err)
+	}
+	resp.Body.Close()
+
+	if len(tp.spans) != 1 {
+		t.Fatalf("Expected 1 span, got %d", len(tp.spans))
+	}
+	s := tp.spans[0]
+	if !s.ended {
+		t.Error("span was not ended")
+	}
+	if s.name != "owm.weather" {
+		t.Errorf("Expected span name owm.weather, got %s", s.name)
+	}
+	if s.status != codes.Error {
+		t.Errorf("Expected error status, got %v", s.status)
+	}
+
+	attrs := s.attrs
+	if v := attrs["owm.location.q"]; v.AsString() != "Philadelphia" {
+		t.Errorf("Expected location attribute Philadelphia, got %q", v.AsString())
+	}
+	if v := attrs["http.status_code"]; v.AsInt64() != http.StatusNotFound {
+		t.Errorf("Expected status code %d, got %d", http.StatusNotFound, v.AsInt64())
+	}
+	if _, ok := attrs["owm.location.appid"]; ok {
+		t.Error("API key leaked into span attributes")
+	}
+	if v, ok := attrs["owm.retry_attempt"]; !ok || v.AsInt64() != 0 {
+		t.Errorf("Expected retry attempt 0, got %v", v.AsInt64())
+	}
+}
+
+func TestTransportRetryAttempt(t *testing.T) {
+	t.Parallel()
+
+	tp := &recorder{}
+	rejected := strings.Repeat("a", 32)
+
+	next := roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		rec := httptest.NewRecorder()
+		if r.URL.Query().Get("appid") == rejected {
+			rec.WriteHeader(http.StatusTooManyRequests)
+		}
+		fmt.Fprint(rec, `{"name":"Dublin"}`)
+		return rec.Result(), nil
+	})
+	hc := &http.Client{Transport: Transport(next, WithTracerProvider(tp))}
+	c, err := owm.NewClient("C", "EN", rejected, owm.WithHttpClient(hc),
+		owm.WithAPIKeys(owm.RoundRobin, rejected, strings.Repeat("b", 32)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.CurrentByName(context.Background(), "Dublin"); err != nil {
+		t.Fatal(err)
+	}
+
+	if len(tp.spans) != 2 {
+		t.Fatalf("Expected 2 spans, got %d", len(tp.spans))
+	}
+	for i, s := range tp.spans {
+		if v := s.attrs["owm.retry_attempt"]; v.AsInt64() != int64(i) {
+			t.Errorf("span %d: expected retry attempt %d, got %d", i, i, v.AsInt64())
+		}
+	}
+}
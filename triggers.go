@@ -0,0 +1,294 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+var errTriggerID = errors.New("trigger ID required")
+var errAlertID = errors.New("alert ID required")
+
+// TriggerTime is a start or end of the period a trigger watches.  With
+// the expression "after", Amount is milliseconds from now; with "exact",
+// it's a Unix time in milliseconds.
+type TriggerTime struct {
+	Expression string `json:"expression"`
+	Amount     int64  `json:"amount"`
+}
+
+// TriggerAfter returns the TriggerTime d from now.
+func TriggerAfter(d time.Duration) TriggerTime {
+	return TriggerTime{Expression: "after", Amount: d.Milliseconds()}
+}
+
+// TriggerAt returns the TriggerTime at t.
+func TriggerAt(t time.Time) TriggerTime {
+	return TriggerTime{Expression: "exact", Amount: t.UnixNano() / int64(time.Millisecond)}
+}
+
+// TriggerTimePeriod is the period a trigger watches the forecast for.
+type TriggerTimePeriod struct {
+	Start TriggerTime `json:"start"`
+	End   TriggerTime `json:"end"`
+}
+
+// TriggerCondition compares a weather parameter (temp, pressure,
+// humidity, wind_speed, wind_direction or clouds) with Amount using
+// Expression ($gt, $gte, $lt, $lte, $eq or $ne).  Temperatures are in
+// kelvin.
+type TriggerCondition struct {
+	ID         string  `json:"_id,omitempty"`
+	Name       string  `json:"name"`
+	Expression string  `json:"expression"`
+	Amount     float64 `json:"amount"`
+}
+
+// TriggerArea is a GeoJSON geometry a trigger watches.
+type TriggerArea struct {
+	Type        string          `json:"type"`
+	Coordinates json.RawMessage `json:"coordinates"`
+}
+
+// PointArea returns the TriggerArea of a point.
+func PointArea(location Coordinates) TriggerArea {
+	c, _ := json.Marshal([]float64{location.Longitude, location.Latitude})
+	return TriggerArea{Type: "Point", Coordinates: c}
+}
+
+// PolygonArea returns the TriggerArea of the polygon with the given
+// corners.  The polygon is closed by repeating the first corner.
+func PolygonArea(corners ...Coordinates) TriggerArea {
+	ring := make([][]float64, 0, len(corners)+1)
+	for _, l := range corners {
+		ring = append(ring, []float64{l.Longitude, l.Latitude})
+	}
+	if len(ring) > 0 {
+		ring = append(ring, ring[0])
+	}
+	c, _ := json.Marshal([][][]float64{ring})
+	return TriggerArea{Type: "Polygon", Coordinates: c}
+}
+
+// Trigger watches the forecast for an area and period and raises alerts
+// when its conditions are met.
+type Trigger struct {
+	ID         string                  `json:"_id,omitempty"`
+	TimePeriod TriggerTimePeriod       `json:"time_period"`
+	Conditions []TriggerCondition      `json:"conditions"`
+	Area       []TriggerArea           `json:"area"`
+	Alerts     map[string]TriggerAlert `json:"alerts,omitempty"`
+}
+
+// TriggerAlertCondition holds the forecast values that met a condition.
+type TriggerAlertCondition struct {
+	CurrentValue struct {
+		Min float64 `json:"min"`
+		Max float64 `json:"max"`
+	} `json:"current_value"`
+	Condition TriggerCondition `json:"condition"`
+}
+
+// TriggerAlert is raised when the conditions of a trigger are met.
+// Date is when the conditions are forecast to be met.
+type TriggerAlert struct {
+	ID          string                  `json:"_id"`
+	Conditions  []TriggerAlertCondition `json:"conditions"`
+	LastUpdate  MilliTimestamp          `json:"last_update"`
+	Date        MilliTimestamp          `json:"date"`
+	Coordinates Coordinates             `json:"coordinates"`
+}
+
+// Triggers manages the weather triggers of the API key through the
+// Triggers API 3.0.
+type Triggers struct {
+	c *Client
+}
+
+// Triggers returns the Triggers service of the Client.
+func (c *Client) Triggers() *Triggers {
+	return &Triggers{c: c}
+}
+
+// url returns the URL of the triggers API path, e.g. "/id/history".
+func (t *Triggers) url(path ...string) string {
+	var p string
+	for _, s := range path {
+		p += "/" + url.PathEscape(s)
+	}
+	return t.c.dataURL("triggers") + p + "?" + url.Values{"appid": {t.c.key}}.Encode()
+}
+
+// Create creates a trigger and returns it with its ID.
+func (t *Triggers) Create(ctx context.Context, trigger Trigger) (*Trigger, error) {
+	tr := &Trigger{}
+	if err := t.c.sendJSON(ctx, http.MethodPost, t.url(), trigger, tr); err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+// List returns the triggers of the API key.
+func (t *Triggers) List(ctx context.Context) ([]Trigger, error) {
+	var list []Trigger
+	if err := t.c.sendJSON(ctx, http.MethodGet, t.url(), nil, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Get returns the trigger with the given ID.
+func (t *Triggers) Get(ctx context.Context, id string) (*Trigger, error) {
+	if id == "" {
+		return nil, errTriggerID
+	}
+	tr := &Trigger{}
+	if err := t.c.sendJSON(ctx, http.MethodGet, t.url(id), nil, tr); err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+// Update replaces the trigger with the given ID and returns the updated
+// trigger.
+func (t *Triggers) Update(ctx context.Context, id string, trigger Trigger) (*Trigger, error) {
+	if id == "" {
+		return nil, errTriggerID
+	}
+	trigger.ID = ""
+	trigger.Alerts = nil
+	tr := &Trigger{}
+	if err := t.c.sendJSON(ctx, http.MethodPut, t.url(id), trigger, tr); err != nil {
+		return nil, err
+	}
+	return tr, nil
+}
+
+// Delete deletes the trigger with the given ID.
+func (t *Triggers) Delete(ctx context.Context, id string) error {
+	if id == "" {
+		return errTriggerID
+	}
+	return t.c.sendJSON(ctx, http.MethodDelete, t.url(id), nil, nil)
+}
+
+// Alerts returns the stored alerts of the trigger with the given ID.
+func (t *Triggers) Alerts(ctx context.Context, id string) ([]TriggerAlert, error) {
+	if id == "" {
+		return nil, errTriggerID
+	}
+	var list []TriggerAlert
+	if err := t.c.sendJSON(ctx, http.MethodGet, t.url(id, "history"), nil, &list); err != nil {
+		return nil, err
+	}
+	return list, nil
+}
+
+// Alert returns the stored alert alertID of the trigger with the given
+// ID.
+func (t *Triggers) Alert(ctx context.Context, id, alertID string) (*TriggerAlert, error) {
+	if id == "" {
+		return nil, errTriggerID
+	}
+	if alertID == "" {
+		return nil, errAlertID
+	}
+	a := &TriggerAlert{}
+	if err := t.c.sendJSON(ctx, http.MethodGet, t.url(id, "history", alertID), nil, a); err != nil {
+		return nil, err
+	}
+	return a, nil
+}
+
+// DeleteAlerts deletes the stored alerts of the trigger with the given
+// ID.
+func (t *Triggers) DeleteAlerts(ctx context.Context, id string) error {
+	if id == "" {
+		return errTriggerID
+	}
+	return t.c.sendJSON(ctx, http.MethodDelete, t.url(id, "history"), nil, nil)
+}
+
+// DeleteAlert deletes the stored alert alertID of the trigger with the
+// given ID.
+func (t *Triggers) DeleteAlert(ctx context.Context, id, alertID string) error {
+	if id == "" {
+		return errTriggerID
+	}
+	if alertID == "" {
+		return errAlertID
+	}
+	return t.c.sendJSON(ctx, http.MethodDelete, t.url(id, "history", alertID), nil, nil)
+}
+
+// TriggerParam is a weather parameter trigger conditions compare.  It
+// starts a condition, e.g. Temp().GreaterThan(303).
+type TriggerParam string
+
+// Temp returns the temperature parameter, in kelvin.
+func Temp() TriggerParam { return "temp" }
+
+// Humidity returns the humidity parameter, in %.
+func Humidity() TriggerParam { return "humidity" }
+
+// Pressure returns the pressure parameter, in hPa.
+func Pressure() TriggerParam { return "pressure" }
+
+// WindSpeed returns the wind speed parameter, in m/s.
+func WindSpeed() TriggerParam { return "wind_speed" }
+
+// WindDirection returns the wind direction parameter, in degrees.
+func WindDirection() TriggerParam { return "wind_direction" }
+
+// Cloudiness returns the cloudiness parameter, in %.
+func Cloudiness() TriggerParam { return "clouds" }
+
+func (p TriggerParam) condition(expression string, v float64) TriggerConditions {
+	return TriggerConditions{{Name: string(p), Expression: expression, Amount: v}}
+}
+
+// GreaterThan returns the condition that the parameter is above v.
+func (p TriggerParam) GreaterThan(v float64) TriggerConditions { return p.condition("$gt", v) }
+
+// GreaterOrEqual returns the condition that the parameter is at least v.
+func (p TriggerParam) GreaterOrEqual(v float64) TriggerConditions { return p.condition("$gte", v) }
+
+// LessThan returns the condition that the parameter is below v.
+func (p TriggerParam) LessThan(v float64) TriggerConditions { return p.condition("$lt", v) }
+
+// LessOrEqual returns the condition that the parameter is at most v.
+func (p TriggerParam) LessOrEqual(v float64) TriggerConditions { return p.condition("$lte", v) }
+
+// Equal returns the condition that the parameter is v.
+func (p TriggerParam) Equal(v float64) TriggerConditions { return p.condition("$eq", v) }
+
+// NotEqual returns the condition that the parameter isn't v.
+func (p TriggerParam) NotEqual(v float64) TriggerConditions { return p.condition("$ne", v) }
+
+// TriggerConditions are conditions that must all be met.  They can be
+// assigned to the Conditions of a Trigger, e.g.
+//
+//	Temp().GreaterThan(303).And(Humidity().LessThan(30))
+type TriggerConditions []TriggerCondition
+
+// And returns the conditions of c and other.
+func (c TriggerConditions) And(other TriggerConditions) TriggerConditions {
+	all := make(TriggerConditions, 0, len(c)+len(other))
+	return append(append(all, c...), other...)
+}
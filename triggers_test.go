@@ -0,0 +1,158 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testTrigger = `{"_id":"5852816a9aaacb00153134a3","__v":0,"alerts":{"5853dbe27416a400011b1b77":{"conditions":[{"current_value":{"min":263.576,"max":263.576},"condition":{"name":"temp","expression":"$lt","amount":273,"_id":"5852816a9aaacb00153134a5"}}],"last_update":1481802090232,"date":1482181200000,"coordinates":{"lon":37,"lat":53}}},"area":[{"type":"Point","_id":"5852816a9aaacb00153134a6","coordinates":[37,53]}],"conditions":[{"name":"temp","expression":"$lt","amount":273,"_id":"5852816a9aaacb00153134a5"}],"time_period":{"end":{"amount":432000000,"expression":"after"},"start":{"amount":132000000,"expression":"after"}}}`
+
+func TestTriggers(t *testing.T) {
+	t.Parallel()
+
+	var requests []string
+	var created string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+		switch {
+		case r.Method == http.MethodPost:
+			b, _ := ioutil.ReadAll(r.Body)
+			created = string(b)
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprint(w, testTrigger)
+		case r.Method == http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		case r.URL.Path == "/data/3.0/triggers":
+			fmt.Fprint(w, "["+testTrigger+"]")
+		case r.URL.Path == "/data/3.0/triggers/5852816a9aaacb00153134a3/history":
+			fmt.Fprint(w, `[{"_id":"5853dbe27416a400011b1b77","date":1482181200000,"coordinates":{"lon":37,"lat":53}}]`)
+		case r.URL.Path == "/data/3.0/triggers/5852816a9aaacb00153134a3/history/5853dbe27416a400011b1b77":
+			fmt.Fprint(w, `{"_id":"5853dbe27416a400011b1b77","date":1482181200000}`)
+		default:
+			fmt.Fprint(w, testTrigger)
+		}
+	})
+
+	ctx := context.Background()
+	tr := c.Triggers()
+	trigger := Trigger{
+		TimePeriod: TriggerTimePeriod{Start: TriggerAfter(132000 * time.Second), End: TriggerAfter(5 * 24 * time.Hour)},
+		Conditions: []TriggerCondition{{Name: "temp", Expression: "$lt", Amount: 273}},
+		Area:       []TriggerArea{PointArea(Coordinates{Latitude: 53, Longitude: 37})},
+	}
+	got, err := tr.Create(ctx, trigger)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"time_period":{"start":{"expression":"after","amount":132000000},"end":{"expression":"after","amount":432000000}},"conditions":[{"name":"temp","expression":"$lt","amount":273}],"area":[{"type":"Point","coordinates":[37,53]}]}`
+	if created != want {
+		t.Errorf("Expected body %s, got %s", want, created)
+	}
+	if got.ID != "5852816a9aaacb00153134a3" || len(got.Alerts) != 1 || got.Alerts["5853dbe27416a400011b1b77"].Conditions[0].CurrentValue.Min != 263.576 {
+		t.Errorf("unexpected trigger %+v", got)
+	}
+
+	if list, err := tr.List(ctx); err != nil || len(list) != 1 {
+		t.Errorf("unexpected triggers %+v, %v", list, err)
+	}
+	if _, err := tr.Get(ctx, got.ID); err != nil {
+		t.Error(err)
+	}
+	if _, err := tr.Update(ctx, got.ID, *got); err != nil {
+		t.Error(err)
+	}
+	alerts, err := tr.Alerts(ctx, got.ID)
+	if err != nil || len(alerts) != 1 || alerts[0].Coordinates.Latitude != 53 {
+		t.Errorf("unexpected alerts %+v, %v", alerts, err)
+	}
+	if a, err := tr.Alert(ctx, got.ID, alerts[0].ID); err != nil || a.Date.UnixMillis() != 1482181200000 {
+		t.Errorf("unexpected alert %+v, %v", a, err)
+	}
+	if err := tr.DeleteAlert(ctx, got.ID, alerts[0].ID); err != nil {
+		t.Error(err)
+	}
+	if err := tr.DeleteAlerts(ctx, got.ID); err != nil {
+		t.Error(err)
+	}
+	if err := tr.Delete(ctx, got.ID); err != nil {
+		t.Error(err)
+	}
+
+	wantRequests := []string{
+		"POST /data/3.0/triggers",
+		"GET /data/3.0/triggers",
+		"GET /data/3.0/triggers/5852816a9aaacb00153134a3",
+		"PUT /data/3.0/triggers/5852816a9aaacb00153134a3",
+		"GET /data/3.0/triggers/5852816a9aaacb00153134a3/history",
+		"GET /data/3.0/triggers/5852816a9aaacb00153134a3/history/5853dbe27416a400011b1b77",
+		"DELETE /data/3.0/triggers/5852816a9aaacb00153134a3/history/5853dbe27416a400011b1b77",
+		"DELETE /data/3.0/triggers/5852816a9aaacb00153134a3/history",
+		"DELETE /data/3.0/triggers/5852816a9aaacb00153134a3",
+	}
+	if fmt.Sprint(requests) != fmt.Sprint(wantRequests) {
+		t.Errorf("Expected requests %v, got %v", wantRequests, requests)
+	}
+
+	if _, err := tr.Get(ctx, ""); err != errTriggerID {
+		t.Errorf("Expected %v, got %v", errTriggerID, err)
+	}
+	if err := tr.DeleteAlert(ctx, got.ID, ""); err != errAlertID {
+		t.Errorf("Expected %v, got %v", errAlertID, err)
+	}
+}
+
+func TestTriggerAreas(t *testing.T) {
+	t.Parallel()
+
+	a := PolygonArea(Coordinates{Latitude: 53, Longitude: 37}, Coordinates{Latitude: 54, Longitude: 37}, Coordinates{Latitude: 54, Longitude: 38})
+	b, err := json.Marshal(a)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `{"type":"Polygon","coordinates":[[[37,53],[37,54],[38,54],[37,53]]]}`
+	if string(b) != want {
+		t.Errorf("Expected %s, got %s", want, b)
+	}
+
+	at := TriggerAt(time.Unix(1482181200, 0))
+	if at.Expression != "exact" || at.Amount != 1482181200000 {
+		t.Errorf("unexpected time %+v", at)
+	}
+}
+
+func TestTriggerConditions(t *testing.T) {
+	t.Parallel()
+
+	conds := Temp().GreaterThan(303).And(Humidity().LessThan(30)).And(WindSpeed().GreaterOrEqual(10))
+	b, err := json.Marshal(conds)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := `[{"name":"temp","expression":"$gt","amount":303},{"name":"humidity","expression":"$lt","amount":30},{"name":"wind_speed","expression":"$gte","amount":10}]`
+	if string(b) != want {
+		t.Errorf("Expected %s, got %s", want, b)
+	}
+
+	trigger := Trigger{Conditions: Cloudiness().Equal(100)}
+	if len(trigger.Conditions) != 1 || trigger.Conditions[0].Name != "clouds" || trigger.Conditions[0].Expression != "$eq" {
+		t.Errorf("unexpected conditions %+v", trigger.Conditions)
+	}
+}
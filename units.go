@@ -0,0 +1,141 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"math"
+	"strconv"
+)
+
+// Conversion factors used by the unit helpers.
+const (
+	knotsPerMeterPerSecond = 1.943844
+	mphPerMeterPerSecond   = 2.236936
+	metersPerStatuteMile   = 1609.344
+	metersPerNauticalMile  = 1852.0
+	metersPerKilometer     = 1000.0
+	hectopascalsPerInHg    = 33.863886
+	kelvinOffset           = 273.15
+)
+
+// beaufortLimits holds the upper wind speed, in m/s, of Beaufort forces
+// 0 to 11.
+var beaufortLimits = []float64{0.5, 1.5, 3.3, 5.5, 7.9, 10.7, 13.8, 17.1, 20.7, 24.4, 28.4, 32.6}
+
+// CelsiusToFahrenheit converts a temperature from celsius to fahrenheit.
+func CelsiusToFahrenheit(c float64) float64 { return c*9/5 + 32 }
+
+// FahrenheitToCelsius converts a temperature from fahrenheit to celsius.
+func FahrenheitToCelsius(f float64) float64 { return (f - 32) * 5 / 9 }
+
+// KelvinToCelsius converts a temperature from kelvin to celsius.
+func KelvinToCelsius(k float64) float64 { return k - kelvinOffset }
+
+// MetersPerSecondToKnots converts a speed from m/s to knots.
+func MetersPerSecondToKnots(ms float64) float64 { return ms * knotsPerMeterPerSecond }
+
+// MetersPerSecondToMph converts a speed from m/s to miles per hour.
+func MetersPerSecondToMph(ms float64) float64 { return ms * mphPerMeterPerSecond }
+
+// MphToMetersPerSecond converts a speed from miles per hour to m/s.
+func MphToMetersPerSecond(mph float64) float64 { return mph / mphPerMeterPerSecond }
+
+// MetersToStatuteMiles converts a distance from meters to statute miles.
+func MetersToStatuteMiles(m float64) float64 { return m / metersPerStatuteMile }
+
+// MetersToNauticalMiles converts a distance from meters to nautical
+// miles.
+func MetersToNauticalMiles(m float64) float64 { return m / metersPerNauticalMile }
+
+// HectopascalsToInHg converts a pressure from hPa to inches of mercury.
+func HectopascalsToInHg(hpa float64) float64 { return hpa / hectopascalsPerInHg }
+
+// Beaufort returns the Beaufort force, 0 to 12, of a wind speed in m/s.
+func Beaufort(ms float64) int {
+	for force, limit := range beaufortLimits {
+		if ms < limit {
+			return force
+		}
+	}
+	return len(beaufortLimits)
+}
+
+// Celsius converts temp, given in the units of unit ("metric",
+// "imperial" or "internal" as stored in the Unit field of the results),
+// to celsius.
+func Celsius(temp float64, unit string) float64 {
+	c, _ := FromAPI(QuantityTemperature, temp, unit, "°C")
+	return c
+}
+
+// MetersPerSecond converts speed, given in the units of unit, to m/s.
+// OWM returns speeds in m/s for metric and internal units and in miles
+// per hour for imperial units.
+func MetersPerSecond(speed float64, unit string) float64 {
+	ms, _ := FromAPI(QuantitySpeed, speed, unit, "m/s")
+	return ms
+}
+
+// distanceSymbols maps the values of the Unit field of the results to
+// the symbols of the units distances are shown in.
+var distanceSymbols = map[string]string{
+	"metric":   "km",
+	"imperial": "mi",
+	"internal": "km",
+}
+
+// Distance converts a distance in meters, such as the visibility, to
+// the units of unit: statute miles for imperial units and kilometers
+// otherwise.
+func Distance(meters float64, unit string) float64 {
+	d, _ := FromAPI(QuantityDistance, meters, unit, DistanceUnit(unit))
+	return d
+}
+
+// DistanceUnit returns the symbol of the distance returned by Distance
+// for unit, "mi" or "km".
+func DistanceUnit(unit string) string {
+	if s, ok := distanceSymbols[unit]; ok {
+		return s
+	}
+	return distanceSymbols["metric"]
+}
+
+// Percent formats a fraction from 0 to 1, such as the probability of
+// precipitation (Pop) of forecasts, as a whole percentage, e.g. "40%".
+func Percent(fraction float64) string {
+	return strconv.Itoa(int(math.Round(fraction*100))) + "%"
+}
+
+// DewPoint returns the dew point, in celsius, for a temperature in
+// celsius and a relative humidity in percent, using the Magnus formula.
+func DewPoint(temp float64, humidity int) float64 {
+	const b, c = 17.62, 243.12
+	if humidity <= 0 {
+		return math.Inf(-1)
+	}
+	gamma := math.Log(float64(humidity)/100) + b*temp/(c+temp)
+	return c * gamma / (b - gamma)
+}
+
+// CompassPoint returns the 8 point compass direction, e.g. "NE", of deg
+// degrees.
+func CompassPoint(deg float64) string {
+	points := []string{"N", "NE", "E", "SE", "S", "SW", "W", "NW"}
+	i := int(math.Floor(math.Mod(deg+22.5, 360)/45)) % len(points)
+	if i < 0 {
+		i += len(points)
+	}
+	return points[i]
+}
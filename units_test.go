@@ -0,0 +1,100 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+package openweathermap
+
+import (
+	"math"
+	"testing"
+)
+
+func almostEqual(a, b float64) bool { return math.Abs(a-b) < 0.01 }
+
+func TestUnitConversions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name      string
+		got, want float64
+	}{
+		{"CelsiusToFahrenheit", CelsiusToFahrenheit(18), 64.4},
+		{"FahrenheitToCelsius", FahrenheitToCelsius(-40), -40},
+		{"KelvinToCelsius", KelvinToCelsius(273.15), 0},
+		{"MetersPerSecondToKnots", MetersPerSecondToKnots(10), 19.44},
+		{"MetersPerSecondToMph", MetersPerSecondToMph(10), 22.37},
+		{"MphToMetersPerSecond", MphToMetersPerSecond(22.369), 10},
+		{"MetersToStatuteMiles", MetersToStatuteMiles(1609.344), 1},
+		{"MetersToNauticalMiles", MetersToNauticalMiles(10000), 5.4},
+		{"HectopascalsToInHg", HectopascalsToInHg(1013.25), 29.92},
+		{"DewPoint", DewPoint(20, 50), 9.26},
+		{"DewPoint saturated", DewPoint(15, 100), 15},
+		{"Celsius imperial", Celsius(212, "imperial"), 100},
+		{"Celsius internal", Celsius(300, "internal"), 26.85},
+		{"Celsius metric", Celsius(21.5, "metric"), 21.5},
+		{"MetersPerSecond imperial", MetersPerSecond(22.369, "imperial"), 10},
+		{"MetersPerSecond metric", MetersPerSecond(10, "metric"), 10},
+		{"Distance imperial", Distance(16093.44, "imperial"), 10},
+		{"Distance metric", Distance(10000, "metric"), 10},
+		{"Distance internal", Distance(2500, "internal"), 2.5},
+	}
+
+	for _, tt := range tests {
+		if !almostEqual(tt.got, tt.want) {
+			t.Errorf("%s: expected %v, got %v", tt.name, tt.want, tt.got)
+		}
+	}
+}
+
+func TestDistanceUnit(t *testing.T) {
+	t.Parallel()
+
+	if u := DistanceUnit("imperial"); u != "mi" {
+		t.Errorf("Expected mi, got %s", u)
+	}
+	if u := DistanceUnit("metric"); u != "km" {
+		t.Errorf("Expected km, got %s", u)
+	}
+}
+
+func TestPercent(t *testing.T) {
+	t.Parallel()
+
+	tests := map[float64]string{0: "0%", 0.4: "40%", 0.275: "28%", 1: "100%"}
+	for fraction, want := range tests {
+		if got := Percent(fraction); got != want {
+			t.Errorf("Percent(%v): expected %s, got %s", fraction, want, got)
+		}
+	}
+}
+
+func TestBeaufort(t *testing.T) {
+	t.Parallel()
+
+	tests := map[float64]int{0: 0, 1: 1, 5.4: 3, 8: 5, 20.8: 9, 32.5: 11, 40: 12}
+	for ms, want := range tests {
+		if got := Beaufort(ms); got != want {
+			t.Errorf("Beaufort(%v) = %d, want %d", ms, got, want)
+		}
+	}
+}
+
+func TestCompassPoint(t *testing.T) {
+	t.Parallel()
+
+	tests := map[float64]string{0: "N", 22: "N", 23: "NE", 180: "S", 225: "SW", 350: "N", 359.9: "N", -45: "NW"}
+	for deg, want := range tests {
+		if got := CompassPoint(deg); got != want {
+			t.Errorf("CompassPoint(%v) = %s, want %s", deg, got, want)
+		}
+	}
+}
@@ -1,9 +1,11 @@
 package openweathermap
 
 import (
-	"encoding/json"
+	"context"
 	"errors"
 	"fmt"
+	"net/url"
+	"strconv"
 	"time"
 )
 
@@ -11,8 +13,8 @@ var errInvalidUVIndex = errors.New("invalid UV index value")
 
 // UVDataPoints holds the UV specific data
 type UVDataPoints struct {
-	DT    int64   `json:"dt"`
-	Value float64 `json:"value"`
+	DT    Timestamp `json:"dt"`
+	Value float64   `json:"value"`
 }
 
 // UV contains the response from the OWM UV API
@@ -23,8 +25,8 @@ type UV struct {
 		DT    int64   `json:"dt"`
 		Value float64 `json:"value"`
 	} `json:"data,omitempty"`*/
-	DT    int64   `json:"dt,omitempty"`
-	Value float64 `json:"value,omitempty"`
+	DT    Timestamp `json:"dt,omitempty"`
+	Value float64   `json:"value,omitempty"`
 	Key   string
 	*Settings
 }
@@ -48,13 +50,13 @@ func NewUV(key string, options ...Option) (*UV, error) {
 
 // Current gets the current UV data for the given coordinates
 func (u *UV) Current(coord *Coordinates) error {
-	response, err := u.client.Get(fmt.Sprintf("%suvi?lat=%f&lon=%f&appid=%s", uvURL, coord.Latitude, coord.Longitude, u.Key))
+	response, err := u.client.Get(fmt.Sprintf("%s?lat=%f&lon=%f&appid=%s", u.dataURL("uvi"), coord.Latitude, coord.Longitude, u.Key))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if err = json.NewDecoder(response.Body).Decode(&u); err != nil {
+	if err = u.decode(response, &u); err != nil {
 		return err
 	}
 
@@ -63,13 +65,13 @@ func (u *UV) Current(coord *Coordinates) error {
 
 // Historical gets the historical UV data for the coordinates and times
 func (u *UV) Historical(coord *Coordinates, start, end time.Time) error {
-	response, err := u.client.Get(fmt.Sprintf("%shistory?lat=%f&lon=%f&start=%d&end=%d&appid=%s", uvURL, coord.Latitude, coord.Longitude, start.Unix(), end.Unix(), u.Key))
+	response, err := u.client.Get(fmt.Sprintf("%s?lat=%f&lon=%f&start=%d&end=%d&appid=%s", u.dataURL("uvi/history"), coord.Latitude, coord.Longitude, start.Unix(), end.Unix(), u.Key))
 	if err != nil {
 		return err
 	}
 	defer response.Body.Close()
 
-	if err = json.NewDecoder(response.Body).Decode(&u); err != nil {
+	if err = u.decode(response, &u); err != nil {
 		return err
 	}
 
@@ -126,45 +128,110 @@ var UVData = []UVIndexInfo{
 	},
 }
 
+// UVCategory returns the exposure category of a UV index value: Low
+// below 3, Moderate below 6, High below 8, Very high below 11 and
+// Extreme from 11.
+func UVCategory(value float64) (UVIndexInfo, error) {
+	switch {
+	case value < 0:
+		return UVIndexInfo{}, errInvalidUVIndex
+	case value < 3:
+		return UVData[0], nil
+	case value < 6:
+		return UVData[1], nil
+	case value < 8:
+		return UVData[2], nil
+	case value < 11:
+		return UVData[3], nil
+	}
+	return UVData[4], nil
+}
+
 // UVInformation provides information on the given UV data which includes the severity
 // and "Recommended protection"
 func (u *UV) UVInformation() ([]UVIndexInfo, error) {
 	switch {
 	case u.Value != 0:
-		switch {
-		case u.Value < 2.9:
-			return []UVIndexInfo{UVData[0]}, nil
-		case u.Value > 3 && u.Value < 5.9:
-			return []UVIndexInfo{UVData[1]}, nil
-		case u.Value > 6 && u.Value < 7.9:
-			return []UVIndexInfo{UVData[2]}, nil
-		case u.Value > 8 && u.Value < 10.9:
-			return []UVIndexInfo{UVData[3]}, nil
-		case u.Value >= 11:
-			return []UVIndexInfo{UVData[4]}, nil
-		default:
-			return nil, errInvalidUVIndex
+		info, err := UVCategory(u.Value)
+		if err != nil {
+			return nil, err
 		}
+		return []UVIndexInfo{info}, nil
 
 	case len(u.Data) > 0:
 		var uvi []UVIndexInfo
 		for _, i := range u.Data {
-			switch {
-			case i.Value < 2.9:
-				uvi = append(uvi, UVData[0])
-			case i.Value > 3 && u.Value < 5.9:
-				uvi = append(uvi, UVData[1])
-			case i.Value > 6 && u.Value < 7.9:
-				uvi = append(uvi, UVData[2])
-			case i.Value > 8 && u.Value < 10.9:
-				uvi = append(uvi, UVData[3])
-			case i.Value >= 11:
-				uvi = append(uvi, UVData[4])
-			default:
-				return nil, errInvalidUVIndex
+			info, err := UVCategory(i.Value)
+			if err != nil {
+				return nil, err
 			}
+			uvi = append(uvi, info)
 		}
+		return uvi, nil
 	}
 
 	return nil, nil
 }
+
+// UVIndexData holds a UV index value for a location and time, as
+// returned by the UV index API.
+type UVIndexData struct {
+	Latitude  float64   `json:"lat"`
+	Longitude float64   `json:"lon"`
+	DateISO   string    `json:"date_iso"`
+	Date      Timestamp `json:"date"`
+	Value     float64   `json:"value"`
+}
+
+// Category returns the exposure category of the UV index.
+func (d *UVIndexData) Category() (UVIndexInfo, error) {
+	return UVCategory(d.Value)
+}
+
+// uvIndex requests the UV index endpoint path with the given parameters.
+func (c *Client) uvIndex(ctx context.Context, path string, params url.Values, v interface{}) error {
+	return c.get(ctx, c.dataURL(path)+"?"+c.query(params).Encode(), v)
+}
+
+// UVIndex returns the current UV index for the provided location
+// coordinates.
+func (c *Client) UVIndex(ctx context.Context, location *Coordinates) (*UVIndexData, error) {
+	d := &UVIndexData{}
+	if err := c.uvIndex(ctx, "uvi", coordinateParams(location), d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// UVIndexForecast returns the daily UV index forecast for the next cnt
+// days, 1 to 8, for the provided location coordinates.
+func (c *Client) UVIndexForecast(ctx context.Context, location *Coordinates, cnt int) ([]UVIndexData, error) {
+	if cnt < 1 || cnt > 8 {
+		return nil, errForecastCount
+	}
+	params := coordinateParams(location)
+	params.Set("cnt", strconv.Itoa(cnt))
+
+	var d []UVIndexData
+	if err := c.uvIndex(ctx, "uvi/forecast", params, &d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// UVIndexHistory returns the daily UV index from start to end for the
+// provided location coordinates.
+func (c *Client) UVIndexHistory(ctx context.Context, location *Coordinates, start, end time.Time) ([]UVIndexData, error) {
+	if !start.Before(end) {
+		return nil, errInvalidTimeRange
+	}
+	params := coordinateParams(location)
+	params.Set("start", strconv.FormatInt(start.Unix(), 10))
+	params.Set("end", strconv.FormatInt(end.Unix(), 10))
+
+	var d []UVIndexData
+	if err := c.uvIndex(ctx, "uvi/history", params, &d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
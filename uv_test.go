@@ -1,6 +1,8 @@
 package openweathermap
 
 import (
+	"context"
+	"fmt"
 	"net/http"
 	"os"
 	"reflect"
@@ -138,3 +140,101 @@ func TestUVInformation(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestUVCategory(t *testing.T) {
+	t.Parallel()
+
+	tests := map[float64]string{0: "Low", 2.95: "Low", 3: "Moderate", 5.95: "Moderate", 7.5: "High", 10.95: "Very high", 11: "Extreme", 14.2: "Extreme"}
+	for value, want := range tests {
+		info, err := UVCategory(value)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if info.Risk != want {
+			t.Errorf("UVCategory(%v) = %s, want %s", value, info.Risk, want)
+		}
+	}
+
+	if _, err := UVCategory(-1); err != errInvalidUVIndex {
+		t.Errorf("Expected %v, got %v", errInvalidUVIndex, err)
+	}
+}
+
+func TestUVInformationData(t *testing.T) {
+	t.Parallel()
+
+	u := &UV{Data: []UVDataPoints{{Value: 1.2}, {Value: 6.4}, {Value: 9.7}}}
+	info, err := u.UVInformation()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(info) != 3 || info[0].Risk != "Low" || info[1].Risk != "High" || info[2].Risk != "Very high" {
+		t.Errorf("unexpected information %+v", info)
+	}
+}
+
+func TestClientUVIndex(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.Path)
+		q := r.URL.Query()
+		switch r.URL.Path {
+		case "/data/2.5/uvi":
+			fmt.Fprint(w, `{"lat":37.75,"lon":-122.37,"date_iso":"2017-06-23T12:00:00Z","date":1498219200,"value":10.16}`)
+		case "/data/2.5/uvi/forecast":
+			if q.Get("cnt") != "2" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `[{"lat":37.75,"lon":-122.37,"date_iso":"2017-06-24T12:00:00Z","date":1498305600,"value":9.8},
+{"lat":37.75,"lon":-122.37,"date_iso":"2017-06-25T12:00:00Z","date":1498392000,"value":11.2}]`)
+		case "/data/2.5/uvi/history":
+			if q.Get("start") != "1498176000" || q.Get("end") != "1498262400" {
+				t.Errorf("unexpected query %s", r.URL.RawQuery)
+			}
+			fmt.Fprint(w, `[{"lat":37.75,"lon":-122.37,"date_iso":"2017-06-23T12:00:00Z","date":1498219200,"value":10.16}]`)
+		}
+	}, WithStrictDecoding())
+
+	ctx := context.Background()
+	location := &Coordinates{Latitude: 37.75, Longitude: -122.37}
+
+	d, err := c.UVIndex(ctx, location)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if d.Value != 10.16 || d.Date.Unix() != 1498219200 {
+		t.Errorf("unexpected result %+v", d)
+	}
+	if info, _ := d.Category(); info.Risk != "Very high" {
+		t.Errorf("Expected Very high, got %s", info.Risk)
+	}
+
+	f, err := c.UVIndexForecast(ctx, location, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f) != 2 || f[1].Value != 11.2 {
+		t.Errorf("unexpected forecast %+v", f)
+	}
+
+	start := time.Unix(1498176000, 0)
+	h, err := c.UVIndexHistory(ctx, location, start, start.Add(24*time.Hour))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(h) != 1 || h[0].DateISO != "2017-06-23T12:00:00Z" {
+		t.Errorf("unexpected history %+v", h)
+	}
+
+	if _, err := c.UVIndexForecast(ctx, location, 9); err != errForecastCount {
+		t.Errorf("Expected %v, got %v", errForecastCount, err)
+	}
+	if _, err := c.UVIndexHistory(ctx, location, start, start); err != errInvalidTimeRange {
+		t.Errorf("Expected %v, got %v", errInvalidTimeRange, err)
+	}
+	if len(paths) != 3 {
+		t.Errorf("Expected 3 requests, got %v", paths)
+	}
+}
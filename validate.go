@@ -0,0 +1,92 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"fmt"
+	"math"
+)
+
+// CoordinateTolerance is the largest difference, in degrees, accepted
+// by CheckCoordinates between requested and returned coordinates.  OWM
+// rounds coordinates and snaps them to its grid, so an exact match can't
+// be expected.
+var CoordinateTolerance = 0.1
+
+// ValidationError is returned when decoded data violates an invariant
+// the API is expected to uphold.
+type ValidationError struct {
+	Invariant string // the violated invariant, e.g. "list sorted by dt"
+	Detail    string // what was found instead
+}
+
+// Error implements the error interface.
+func (e *ValidationError) Error() string {
+	return fmt.Sprintf("openweathermap: invariant %q violated: %s", e.Invariant, e.Detail)
+}
+
+// checkList makes sure a forecast list is sorted by time and its length
+// matches the cnt field of the response.
+func checkList(cnt int, dts []int64) error {
+	if cnt != len(dts) {
+		return &ValidationError{
+			Invariant: "cnt matches list length",
+			Detail:    fmt.Sprintf("cnt is %d but the list holds %d entries", cnt, len(dts)),
+		}
+	}
+	for i := 1; i < len(dts); i++ {
+		if dts[i] <= dts[i-1] {
+			return &ValidationError{
+				Invariant: "list sorted by dt",
+				Detail:    fmt.Sprintf("entry %d (dt %d) does not follow entry %d (dt %d)", i, dts[i], i-1, dts[i-1]),
+			}
+		}
+	}
+	return nil
+}
+
+// Validate checks the forecast list is sorted by time and matches the
+// returned count.
+func (f *Forecast5WeatherData) Validate() error {
+	dts := make([]int64, len(f.List))
+	for i, l := range f.List {
+		dts[i] = l.Dt.UnixSeconds()
+	}
+	return checkList(f.Cnt, dts)
+}
+
+// Validate checks the forecast list is sorted by time and matches the
+// returned count.
+func (f *Forecast16WeatherData) Validate() error {
+	dts := make([]int64, len(f.List))
+	for i, l := range f.List {
+		dts[i] = l.Dt.UnixSeconds()
+	}
+	return checkList(f.Cnt, dts)
+}
+
+// CheckCoordinates makes sure the coordinates returned by the API are
+// within CoordinateTolerance of the requested ones.
+func CheckCoordinates(requested, returned *Coordinates) error {
+	if math.Abs(requested.Latitude-returned.Latitude) > CoordinateTolerance ||
+		math.Abs(requested.Longitude-returned.Longitude) > CoordinateTolerance {
+		return &ValidationError{
+			Invariant: "coordinates echo the request",
+			Detail: fmt.Sprintf("requested lat %g lon %g but got lat %g lon %g",
+				requested.Latitude, requested.Longitude, returned.Latitude, returned.Longitude),
+		}
+	}
+	return nil
+}
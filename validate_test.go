@@ -0,0 +1,76 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"testing"
+)
+
+func invariant(err error) string {
+	if err == nil {
+		return ""
+	}
+	return err.(*ValidationError).Invariant
+}
+
+func TestForecast5Validate(t *testing.T) {
+	t.Parallel()
+
+	f := &Forecast5WeatherData{
+		Cnt:  3,
+		List: []Forecast5WeatherList{{Dt: TimestampFromUnix(100)}, {Dt: TimestampFromUnix(200)}, {Dt: TimestampFromUnix(300)}},
+	}
+	if err := f.Validate(); err != nil {
+		t.Error(err)
+	}
+
+	f.Cnt = 4
+	if got := invariant(f.Validate()); got != "cnt matches list length" {
+		t.Errorf("Expected cnt invariant, got %q", got)
+	}
+
+	f.Cnt = 3
+	f.List[2].Dt = TimestampFromUnix(150)
+	if got := invariant(f.Validate()); got != "list sorted by dt" {
+		t.Errorf("Expected sort invariant, got %q", got)
+	}
+}
+
+func TestForecast16Validate(t *testing.T) {
+	t.Parallel()
+
+	f := &Forecast16WeatherData{
+		Cnt:  2,
+		List: []Forecast16WeatherList{{Dt: TimestampFromUnix(200)}, {Dt: TimestampFromUnix(100)}},
+	}
+	if got := invariant(f.Validate()); got != "list sorted by dt" {
+		t.Errorf("Expected sort invariant, got %q", got)
+	}
+}
+
+func TestCheckCoordinates(t *testing.T) {
+	t.Parallel()
+
+	requested := &Coordinates{Longitude: -112.07, Latitude: 33.45}
+
+	if err := CheckCoordinates(requested, &Coordinates{Longitude: -112.0740, Latitude: 33.4484}); err != nil {
+		t.Error(err)
+	}
+
+	err := CheckCoordinates(requested, &Coordinates{Longitude: 112.07, Latitude: 33.45})
+	if got := invariant(err); got != "coordinates echo the request" {
+		t.Errorf("Expected coordinates invariant, got %q", got)
+	}
+}
@@ -0,0 +1,100 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import "regexp"
+
+// Bases of the endpoint URLs.  The hourly and climate forecasts are
+// only served by proHost and One Call only over https.
+const (
+	apiBase       = "http://" + apiHost
+	secureAPIBase = "https://" + apiHost
+	proBase       = "https://" + proHost
+)
+
+// apiEndpoint is an endpoint under /data: the base of its URL and the
+// API version it is requested with by default.
+type apiEndpoint struct {
+	base    string
+	version string
+}
+
+// apiEndpoints holds the endpoints under /data by the label Endpoint
+// returns for them.  Their URLs are built from it by dataURL.
+var apiEndpoints = map[string]apiEndpoint{
+	"weather":                           {apiBase, "2.5"},
+	"find":                              {apiBase, "2.5"},
+	"group":                             {apiBase, "2.5"},
+	"box/city":                          {apiBase, "2.5"},
+	"station":                           {apiBase, "2.5"},
+	"forecast":                          {apiBase, "2.5"},
+	"forecast/daily":                    {apiBase, "2.5"},
+	"forecast/hourly":                   {proBase, "2.5"},
+	"forecast/climate":                  {proBase, "2.5"},
+	"history/city":                      {apiBase, "2.5"},
+	"history/accumulated_precipitation": {apiBase, "2.5"},
+	"history/accumulated_temperature":   {apiBase, "2.5"},
+	"air_pollution":                     {apiBase, "2.5"},
+	"air_pollution/history":             {apiBase, "2.5"},
+	"uvi":                               {apiBase, "2.5"},
+	"uvi/forecast":                      {apiBase, "2.5"},
+	"uvi/history":                       {apiBase, "2.5"},
+	"onecall":                           {secureAPIBase, "3.0"},
+	"onecall/timemachine":               {secureAPIBase, "3.0"},
+	"onecall/day_summary":               {secureAPIBase, "3.0"},
+	"onecall/overview":                  {secureAPIBase, "3.0"},
+	"stations":                          {apiBase, "3.0"},
+	"measurements":                      {apiBase, "3.0"},
+	"triggers":                          {apiBase, "3.0"},
+}
+
+var versionPattern = regexp.MustCompile(`^[0-9]+\.[0-9]+$`)
+
+// APIVersion returns the API version endpoint, a label as returned by
+// Endpoint such as "weather" or "onecall/timemachine", is requested with
+// by default.
+func APIVersion(endpoint string) (string, bool) {
+	e, ok := apiEndpoints[endpoint]
+	return e.version, ok
+}
+
+// WithAPIVersion requests endpoint, a label as returned by Endpoint such
+// as "weather" or "onecall/timemachine", with the API version version,
+// e.g. "3.0", instead of its default.  This keeps a Client working when
+// OWM moves an endpoint to a new version before the library follows.
+func WithAPIVersion(endpoint, version string) Option {
+	return func(s *Settings) error {
+		if _, ok := apiEndpoints[endpoint]; !ok || !versionPattern.MatchString(version) {
+			return errInvalidOption
+		}
+		if s.versions == nil {
+			s.versions = map[string]string{}
+		}
+		s.versions[endpoint] = version
+		return nil
+	}
+}
+
+// dataURL returns the URL, without a query, of endpoint, a label in
+// apiEndpoints such as "weather" or "onecall/timemachine", with the API
+// version set for it by WithAPIVersion or else its default.
+func (s *Settings) dataURL(endpoint string) string {
+	e := apiEndpoints[endpoint]
+	v := e.version
+	if o, ok := s.versions[endpoint]; ok {
+		v = o
+	}
+	return e.base + "/data/" + v + "/" + endpoint
+}
@@ -0,0 +1,73 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestAPIVersionDefaults(t *testing.T) {
+	t.Parallel()
+
+	s := NewSettings()
+	for endpoint, e := range apiEndpoints {
+		u, err := url.Parse(s.dataURL(endpoint))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := Endpoint(u); got != endpoint {
+			t.Errorf("%s: expected endpoint %s, got %s", u, endpoint, got)
+		}
+		if v, ok := APIVersion(endpoint); !ok || v != e.version || !strings.HasPrefix(u.Path, "/data/"+v+"/") {
+			t.Errorf("%s: expected version %s, got %s", u, e.version, v)
+		}
+	}
+	if _, ok := APIVersion("nowcast"); ok {
+		t.Error("Expected no version for an unknown endpoint")
+	}
+}
+
+func TestWithAPIVersion(t *testing.T) {
+	t.Parallel()
+
+	var paths []string
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.Host+r.URL.Path)
+		fmt.Fprint(w, `{}`)
+	}, WithAPIVersion("weather", "3.0"), WithPlan(PlanPaid))
+
+	ctx := context.Background()
+	if _, err := c.CurrentByID(ctx, 2950159); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.Forecast5ByID(ctx, 2950159, 1); err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"pro.openweathermap.org/data/3.0/weather", "pro.openweathermap.org/data/2.5/forecast"}
+	if fmt.Sprint(paths) != fmt.Sprint(want) {
+		t.Errorf("Expected %v, got %v", want, paths)
+	}
+
+	for _, o := range []Option{WithAPIVersion("weather", "v3"), WithAPIVersion("nowcast", "3.0")} {
+		if _, err := NewClient("c", "en", testKey, o); err != errInvalidOption {
+			t.Errorf("Expected %v, got %v", errInvalidOption, err)
+		}
+	}
+}
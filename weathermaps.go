@@ -0,0 +1,155 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"image"
+	"image/color"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var errInvalidTileOptions = errors.New("invalid weather map layer, tile or options")
+
+// WeatherLayer is a layer of the Weather Maps 2.0 API, which needs a
+// paid subscription.
+type WeatherLayer string
+
+// Weather Maps 2.0 layers.
+const (
+	WeatherPrecipitation            WeatherLayer = "PR0"
+	WeatherAccumulatedPrecipitation WeatherLayer = "PA0"
+	WeatherAccumulatedRain          WeatherLayer = "PAR0"
+	WeatherAccumulatedSnow          WeatherLayer = "PAS0"
+	WeatherSnowDepth                WeatherLayer = "SD0"
+	WeatherWindSpeed                WeatherLayer = "WS10"
+	WeatherWind                     WeatherLayer = "WND"
+	WeatherPressure                 WeatherLayer = "APM"
+	WeatherTemperature              WeatherLayer = "TA2"
+	WeatherDewPoint                 WeatherLayer = "TD2"
+	WeatherSoilTemperature          WeatherLayer = "TS0"
+	WeatherSoilTemperature10        WeatherLayer = "TS10"
+	WeatherHumidity                 WeatherLayer = "HRD0"
+	WeatherClouds                   WeatherLayer = "CL"
+)
+
+// validWeatherLayer reports whether l is one of the Weather Maps 2.0
+// layers.
+func validWeatherLayer(l WeatherLayer) bool {
+	switch l {
+	case WeatherPrecipitation, WeatherAccumulatedPrecipitation, WeatherAccumulatedRain,
+		WeatherAccumulatedSnow, WeatherSnowDepth, WeatherWindSpeed, WeatherWind, WeatherPressure,
+		WeatherTemperature, WeatherDewPoint, WeatherSoilTemperature, WeatherSoilTemperature10,
+		WeatherHumidity, WeatherClouds:
+		return true
+	}
+	return false
+}
+
+// PaletteStop maps a value of a weather map layer, in the units of the
+// layer, to a color.  Values between stops are interpolated.
+type PaletteStop struct {
+	Value float64
+	Color color.Color
+}
+
+// TileOptions are the optional parameters of a Weather Maps 2.0 tile.
+// The zero value requests the current map with the default styling.
+type TileOptions struct {
+	// Date is the time of the map, in the past for historical data or
+	// in the future for forecasts.  The zero time is now.
+	Date time.Time
+	// Opacity of the layer from 0 to 1; nil uses the default, 0.8.
+	Opacity *float64
+	// Palette replaces the default colors of the layer.
+	Palette []PaletteStop
+	// FillBound fills values beyond the last palette stops with the
+	// color of the stop instead of leaving them transparent.
+	FillBound bool
+}
+
+// values returns the query parameters for o.
+func (o *TileOptions) values() (url.Values, error) {
+	q := url.Values{}
+	if o == nil {
+		return q, nil
+	}
+	if !o.Date.IsZero() {
+		q.Set("date", strconv.FormatInt(o.Date.Unix(), 10))
+	}
+	if o.Opacity != nil {
+		if *o.Opacity < 0 || *o.Opacity > 1 {
+			return nil, errInvalidTileOptions
+		}
+		q.Set("opacity", strconv.FormatFloat(*o.Opacity, 'f', -1, 64))
+	}
+	if len(o.Palette) > 0 {
+		stops := make([]string, len(o.Palette))
+		for i, s := range o.Palette {
+			if s.Color == nil {
+				return nil, errInvalidTileOptions
+			}
+			stops[i] = strconv.FormatFloat(s.Value, 'f', -1, 64) + ":" + hexColor(s.Color)
+		}
+		q.Set("palette", strings.Join(stops, ";"))
+	}
+	if o.FillBound {
+		q.Set("fill_bound", "true")
+	}
+	return q, nil
+}
+
+// hexColor returns c as RRGGBB, or RRGGBBAA if it isn't opaque.
+func hexColor(c color.Color) string {
+	n := color.NRGBAModel.Convert(c).(color.NRGBA)
+	if n.A == 0xff {
+		return fmt.Sprintf("%02X%02X%02X", n.R, n.G, n.B)
+	}
+	return fmt.Sprintf("%02X%02X%02X%02X", n.R, n.G, n.B, n.A)
+}
+
+// WeatherTileURL returns the URL of the Weather Maps 2.0 tile x, y at
+// zoom level z, 0 to 18, of layer with the options opts, which may be
+// nil.  The URL holds the API key, so it should only be handed to
+// trusted clients.
+func (m *Maps) WeatherTileURL(layer WeatherLayer, z, x, y int, opts *TileOptions) (string, error) {
+	if !validWeatherLayer(layer) || z < 0 || z > maxTileZoom {
+		return "", errInvalidTileOptions
+	}
+	if n := 1 << uint(z); x < 0 || x >= n || y < 0 || y >= n {
+		return "", errInvalidTileOptions
+	}
+	q, err := opts.values()
+	if err != nil {
+		return "", err
+	}
+	q.Set("appid", m.c.key)
+	return fmt.Sprintf(weatherMapURL, layer, z, x, y, q.Encode()), nil
+}
+
+// GetWeatherTile returns the image of the Weather Maps 2.0 tile x, y at
+// zoom level z of layer with the options opts, which may be nil.
+func (m *Maps) GetWeatherTile(ctx context.Context, layer WeatherLayer, z, x, y int, opts *TileOptions) (image.Image, error) {
+	u, err := m.WeatherTileURL(layer, z, x, y, opts)
+	if err != nil {
+		return nil, err
+	}
+	return m.c.getPNG(ctx, u)
+}
@@ -0,0 +1,112 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"bytes"
+	"context"
+	"image"
+	"image/color"
+	"image/png"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestWeatherTileURL(t *testing.T) {
+	t.Parallel()
+
+	m := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {}).Maps()
+
+	u, err := m.WeatherTileURL(WeatherTemperature, 3, 4, 2, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.HasPrefix(u, "https://maps.openweathermap.org/maps/2.0/weather/TA2/3/4/2?") {
+		t.Errorf("unexpected URL %s", u)
+	}
+
+	date := time.Date(2023, 6, 1, 12, 0, 0, 0, time.UTC)
+	u, err = m.WeatherTileURL(WeatherTemperature, 3, 4, 2, &TileOptions{
+		Date:    date,
+		Opacity: Float(0.5),
+		Palette: []PaletteStop{
+			{Value: -10, Color: color.RGBA{B: 0xff, A: 0xff}},
+			{Value: 30, Color: color.NRGBA{R: 0xff, A: 0x80}},
+		},
+		FillBound: true,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	parsed, err := url.Parse(u)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := url.Values{
+		"appid":      {testKey},
+		"date":       {"1685620800"},
+		"opacity":    {"0.5"},
+		"palette":    {"-10:0000FF;30:FF000080"},
+		"fill_bound": {"true"},
+	}
+	if q := parsed.Query(); q.Encode() != want.Encode() {
+		t.Errorf("Expected %v, got %v", want, q)
+	}
+
+	tests := []struct {
+		name  string
+		layer WeatherLayer
+		z     int
+		x     int
+		opts  *TileOptions
+	}{
+		{"layer", "clouds_new", 3, 4, nil},
+		{"zoom", WeatherClouds, 19, 4, nil},
+		{"tile", WeatherClouds, 3, 8, nil},
+		{"opacity", WeatherClouds, 3, 4, &TileOptions{Opacity: Float(1.5)}},
+		{"palette", WeatherClouds, 3, 4, &TileOptions{Palette: []PaletteStop{{Value: 1}}}},
+	}
+	for _, tt := range tests {
+		if _, err := m.WeatherTileURL(tt.layer, tt.z, tt.x, 2, tt.opts); err != errInvalidTileOptions {
+			t.Errorf("%s: expected %v, got %v", tt.name, errInvalidTileOptions, err)
+		}
+	}
+}
+
+func TestGetWeatherTile(t *testing.T) {
+	t.Parallel()
+
+	var buf bytes.Buffer
+	if err := png.Encode(&buf, image.NewNRGBA(image.Rect(0, 0, tileSize, tileSize))); err != nil {
+		t.Fatal(err)
+	}
+	m := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/maps/2.0/weather/PA0/2/1/1" || r.URL.Query().Get("date") == "" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		w.Write(buf.Bytes())
+	}).Maps()
+
+	img, err := m.GetWeatherTile(context.Background(), WeatherAccumulatedPrecipitation, 2, 1, 1, &TileOptions{Date: time.Now().Add(3 * time.Hour)})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if img.Bounds().Dx() != tileSize {
+		t.Errorf("Expected a %d pixel tile, got %v", tileSize, img.Bounds())
+	}
+}
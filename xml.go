@@ -0,0 +1,139 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"time"
+)
+
+var errXMLUnsupported = errors.New("XML mode is only supported for the current weather")
+
+// xmlTime is the layout of the times of XML responses, which are UTC.
+const xmlTime = "2006-01-02T15:04:05"
+
+// xmlValue is an XML element whose value is its value attribute.
+type xmlValue struct {
+	Value float64 `xml:"value,attr"`
+}
+
+// currentXML is the current weather as the API returns it in XML.
+type currentXML struct {
+	City struct {
+		ID    int    `xml:"id,attr"`
+		Name  string `xml:"name,attr"`
+		Coord struct {
+			Lon float64 `xml:"lon,attr"`
+			Lat float64 `xml:"lat,attr"`
+		} `xml:"coord"`
+		Country  string `xml:"country"`
+		Timezone int    `xml:"timezone"`
+		Sun      struct {
+			Rise string `xml:"rise,attr"`
+			Set  string `xml:"set,attr"`
+		} `xml:"sun"`
+	} `xml:"city"`
+	Temperature struct {
+		Value float64 `xml:"value,attr"`
+		Min   float64 `xml:"min,attr"`
+		Max   float64 `xml:"max,attr"`
+	} `xml:"temperature"`
+	FeelsLike xmlValue `xml:"feels_like"`
+	Humidity  xmlValue `xml:"humidity"`
+	Pressure  xmlValue `xml:"pressure"`
+	Wind      struct {
+		Speed     xmlValue `xml:"speed"`
+		Gusts     xmlValue `xml:"gusts"`
+		Direction xmlValue `xml:"direction"`
+	} `xml:"wind"`
+	Clouds        xmlValue `xml:"clouds"`
+	Visibility    xmlValue `xml:"visibility"`
+	Precipitation struct {
+		Value float64 `xml:"value,attr"`
+		Mode  string  `xml:"mode,attr"`
+	} `xml:"precipitation"`
+	Weather []struct {
+		Number int    `xml:"number,attr"`
+		Value  string `xml:"value,attr"`
+		Icon   string `xml:"icon,attr"`
+	} `xml:"weather"`
+	LastUpdate struct {
+		Value string `xml:"value,attr"`
+	} `xml:"lastupdate"`
+}
+
+// currentXML requests the current weather for the given location
+// parameters in XML and decodes it into w.
+func (c *Client) currentXML(ctx context.Context, params url.Values, w *CurrentWeatherData) error {
+	q := c.query(params)
+	q.Set("mode", "xml")
+	response, err := c.send(ctx, c.dataURL("weather")+"?"+q.Encode())
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	var x currentXML
+	if err := c.decodeXML(response, &x); err != nil {
+		return err
+	}
+	return x.fill(w)
+}
+
+// fill sets the weather data of w to those of x.
+func (x *currentXML) fill(w *CurrentWeatherData) error {
+	times := make([]Timestamp, 3)
+	for i, s := range []string{x.City.Sun.Rise, x.City.Sun.Set, x.LastUpdate.Value} {
+		if s == "" {
+			continue
+		}
+		t, err := time.Parse(xmlTime, s)
+		if err != nil {
+			return &DecodeError{Err: err, Excerpt: s}
+		}
+		times[i] = Timestamp{t}
+	}
+
+	w.ID = x.City.ID
+	w.Name = x.City.Name
+	w.GeoPos = Coordinates{Longitude: x.City.Coord.Lon, Latitude: x.City.Coord.Lat}
+	w.Timezone = x.City.Timezone
+	w.Sys = Sys{Country: x.City.Country, Sunrise: times[0], Sunset: times[1]}
+	w.Dt = times[2]
+	w.Main = Main{
+		Temp:      x.Temperature.Value,
+		TempMin:   x.Temperature.Min,
+		TempMax:   x.Temperature.Max,
+		FeelsLike: x.FeelsLike.Value,
+		Pressure:  x.Pressure.Value,
+		Humidity:  int(x.Humidity.Value),
+	}
+	w.Wind = Wind{Speed: x.Wind.Speed.Value, Deg: x.Wind.Direction.Value, Gust: x.Wind.Gusts.Value}
+	w.Clouds = Clouds{All: int(x.Clouds.Value)}
+	w.Visibility = int(x.Visibility.Value)
+	switch x.Precipitation.Mode {
+	case "rain":
+		w.Rain.OneH = x.Precipitation.Value
+	case "snow":
+		w.Snow.OneH = x.Precipitation.Value
+	}
+	w.Weather = nil
+	for _, c := range x.Weather {
+		w.Weather = append(w.Weather, Weather{ID: c.Number, Description: c.Value, Icon: c.Icon})
+	}
+	return nil
+}
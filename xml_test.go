@@ -0,0 +1,104 @@
+// Copyright 2015 Brian J. Downs
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package openweathermap
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+const testCurrentXML = `<?xml version="1.0" encoding="UTF-8"?>
+<current>
+<city id="2643743" name="London"><coord lon="-0.13" lat="51.51"></coord><country>GB</country><timezone>0</timezone><sun rise="2017-01-30T07:40:36" set="2017-01-30T16:47:56"></sun></city>
+<temperature value="7" min="5" max="8" unit="metric"></temperature>
+<feels_like value="4.2" unit="metric"></feels_like>
+<humidity value="81" unit="%"></humidity>
+<pressure value="1012" unit="hPa"></pressure>
+<wind><speed value="4.6" unit="m/s" name="Gentle Breeze"></speed><gusts value=""></gusts><direction value="90" code="E" name="East"></direction></wind>
+<clouds value="90" name="overcast clouds"></clouds>
+<visibility value="10000"></visibility>
+<precipitation value="0.25" mode="rain" unit="1h"></precipitation>
+<weather number="500" value="light rain" icon="10d"></weather>
+<lastupdate value="2017-01-30T15:50:00"></lastupdate>
+</current>`
+
+func TestCurrentXML(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if q := r.URL.Query(); q.Get("mode") != "xml" || q.Get("id") != "2643743" {
+			t.Errorf("unexpected query %v", q)
+		}
+		fmt.Fprint(w, testCurrentXML)
+	}, WithXMLMode())
+
+	w, err := c.CurrentByID(context.Background(), 2643743)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if w.ID != 2643743 || w.Name != "London" || w.Sys.Country != "GB" || !almostEqual(w.GeoPos.Latitude, 51.51) {
+		t.Errorf("unexpected city %+v", w)
+	}
+	if w.Sys.Sunrise.Unix() != 1485762036 || w.Dt.Unix() != 1485791400 || w.Unit != "metric" {
+		t.Errorf("unexpected times %+v", w)
+	}
+	if !almostEqual(w.Main.Temp, 7) || !almostEqual(w.Main.FeelsLike, 4.2) || w.Main.Humidity != 81 || !almostEqual(w.Main.Pressure, 1012) {
+		t.Errorf("unexpected main %+v", w.Main)
+	}
+	if !almostEqual(w.Wind.Speed, 4.6) || !almostEqual(w.Wind.Deg, 90) || w.Wind.Gust != 0 || w.Clouds.All != 90 || w.Visibility != 10000 {
+		t.Errorf("unexpected wind or clouds %+v", w)
+	}
+	if !almostEqual(w.Rain.OneH, 0.25) || len(w.Weather) != 1 || w.Weather[0].ID != 500 || w.Weather[0].Icon != "10d" {
+		t.Errorf("unexpected conditions %+v", w)
+	}
+}
+
+func TestForecastXMLUnsupported(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected request %s", r.URL)
+	}, WithXMLMode())
+
+	ctx := context.Background()
+	if _, err := c.Forecast5ByName(ctx, "London", 8); err != errXMLUnsupported {
+		t.Errorf("Expected %v, got %v", errXMLUnsupported, err)
+	}
+	if _, err := c.Forecast16ByID(ctx, 2643743, 7); err != errXMLUnsupported {
+		t.Errorf("Expected %v, got %v", errXMLUnsupported, err)
+	}
+	if _, err := c.ForecastHourlyByName(ctx, "London", 24); err != errXMLUnsupported {
+		t.Errorf("Expected %v, got %v", errXMLUnsupported, err)
+	}
+	if _, err := c.ClimateForecastByName(ctx, "London", 30); err != errXMLUnsupported {
+		t.Errorf("Expected %v, got %v", errXMLUnsupported, err)
+	}
+}
+
+func TestCurrentXMLMalformed(t *testing.T) {
+	t.Parallel()
+
+	c := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"cod":200}`)
+	}, WithXMLMode())
+
+	var decodeErr *DecodeError
+	if _, err := c.CurrentByName(context.Background(), "London"); !errors.As(err, &decodeErr) {
+		t.Errorf("Expected a *DecodeError, got %v", err)
+	}
+}